@@ -0,0 +1,125 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// State of a command line run, with the streams and environment it works with.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(key string) string
+
+	output   format
+	token    string
+	team     string
+	endpoint string
+}
+
+// Output format of results.
+type format string
+
+const (
+	formatJSON  format = "json"
+	formatTable format = "table"
+	formatYAML  format = "yaml"
+)
+
+func (f *format) String() string {
+	return string(*f)
+}
+
+func (f *format) Set(s string) error {
+	switch v := format(s); v {
+	case formatJSON, formatTable, formatYAML:
+		*f = v
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, want json, table or yaml", s)
+}
+
+// globalFlags registers the flags available to every command. The root command line and the one of
+// a leaf command share them, so the values parsed from the former are the defaults of the latter.
+func (a *app) globalFlags(fs *flag.FlagSet) {
+	if a.output == "" {
+		a.output = formatJSON
+	}
+	fs.Var(&a.output, "o", "output `format`: json, table or yaml")
+	fs.Var(&a.output, "output", "output `format`: json, table or yaml")
+	fs.StringVar(&a.token, "token", a.token, "access `token`, instead of $QIITA_ACCESS_TOKEN or the config file")
+	fs.StringVar(&a.team, "team", a.team, "Qiita:Team `id`, instead of $QIITA_TEAM or the config file")
+	fs.StringVar(&a.endpoint, "endpoint", a.endpoint, "API endpoint `url`, instead of $QIITA_ENDPOINT or the config file")
+}
+
+// Contents of the config file.
+type config struct {
+	Token    string `json:"token"`
+	Team     string `json:"team"`
+	Endpoint string `json:"endpoint"`
+}
+
+// loadConfig reads $QIITA_CONFIG, or qiita/config.json in the user config directory. A missing file is
+// an empty config.
+func (a *app) loadConfig() (*config, error) {
+	var c config
+	name := a.getenv("QIITA_CONFIG")
+	if name == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return &c, nil
+		}
+		name = filepath.Join(dir, "qiita", "config.json")
+	}
+	b, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// client returns a client configured by the flags, the environment variables and the config file,
+// in this order of precedence.
+func (a *app) client() (*qiita.Client, error) {
+	c, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	config := qiita.NewConfig()
+	// The team and the endpoint both choose the API, so they are taken together from the first source
+	// setting either.
+	for _, source := range []struct{ team, endpoint, names string }{
+		{a.team, a.endpoint, "-team and -endpoint"},
+		{a.getenv("QIITA_TEAM"), a.getenv("QIITA_ENDPOINT"), "$QIITA_TEAM and $QIITA_ENDPOINT"},
+		{c.Team, c.Endpoint, `"team" and "endpoint" of the config file`},
+	} {
+		if source.team != "" && source.endpoint != "" {
+			return nil, fmt.Errorf("%s cannot be used together", source.names)
+		}
+		if source.team != "" {
+			config.WithTeam(source.team)
+			break
+		}
+		if source.endpoint != "" {
+			config.WithEndpoint(source.endpoint)
+			break
+		}
+	}
+	return qiita.NewClient(cmp.Or(a.token, a.getenv("QIITA_ACCESS_TOKEN"), c.Token), *config)
+}
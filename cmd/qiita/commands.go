@@ -0,0 +1,837 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitaarchive"
+	"github.com/ktsujichan/qiita-sdk-go/qiitasync"
+)
+
+// Columns of tables of each resource.
+var (
+	itemColumns     = []string{"id", "title", "user.id", "tags.name", "likes_count", "updated_at"}
+	commentColumns  = []string{"id", "user.id", "body", "updated_at"}
+	tagColumns      = []string{"id", "items_count", "followers_count"}
+	userColumns     = []string{"id", "name", "items_count", "followers_count"}
+	projectColumns  = []string{"id", "name", "archived", "updated_at"}
+	templateColumns = []string{"id", "name", "title", "tags.name"}
+	manifestColumns = []string{"user", "completed_at", "counts.items", "counts.comments", "skipped", "truncated"}
+	reportColumns   = []string{"created.items", "created.comments", "existing.items", "existing.comments", "unmigrated.section"}
+)
+
+func group(name, summary string, commands ...*command) *command {
+	return &command{name: name, summary: summary, commands: commands}
+}
+
+func leaf(name, args string, nargs int, summary string, flags func(fs *flag.FlagSet) action) *command {
+	return &command{name: name, args: args, nargs: nargs, summary: summary, flags: flags}
+}
+
+// noFlags is the flags of a command only taking positional arguments.
+func noFlags(act action) func(fs *flag.FlagSet) action {
+	return func(*flag.FlagSet) action { return act }
+}
+
+func commands() *command {
+	return group("", "",
+		group("items", "Create, show, update and delete items", itemCommands()...),
+		group("comments", "Post, show, update and delete comments on items", commentCommands()...),
+		group("tags", "Show and follow tags", tagCommands()...),
+		group("stock", "Stock items", stockCommands()...),
+		group("users", "Show and follow users", userCommands()...),
+		group("projects", "Manage projects (Qiita:Team)", projectCommands()...),
+		group("templates", "Manage templates (Qiita:Team)", templateCommands()...),
+		leaf("sync", "<dir>", 1, "Synchronize Markdown files with front matter in a directory with items", syncCommand),
+		leaf("export", "<dir>", 1, "Export everything of the authenticated user to an archive directory", exportCommand),
+		leaf("import", "<dir>", 1, "Import an archive directory into the authenticated account", importCommand),
+		leaf("completion", "bash|zsh|fish", 1, "Print a shell completion script", noFlags(completion)),
+	)
+}
+
+// Flags of list commands.
+type listFlags struct {
+	page    uint
+	perPage uint
+	all     bool
+}
+
+func pagination(fs *flag.FlagSet) *listFlags {
+	l := &listFlags{}
+	fs.UintVar(&l.page, "page", 1, "page `number`, from 1 to 100")
+	fs.UintVar(&l.perPage, "per-page", 20, "`number` of results per page, from 1 to 100")
+	fs.BoolVar(&l.all, "all", false, "list every page")
+	return l
+}
+
+// list prints a page of results, or every result of all with -all.
+func list[S ~[]E, E any](a *app, l *listFlags, columns []string, page func(page, perPage uint) (*S, *qiita.Page, error), all iter.Seq2[E, error]) error {
+	if l.all {
+		results := S{}
+		for e, err := range all {
+			if err != nil {
+				return err
+			}
+			results = append(results, e)
+		}
+		return a.print(results, columns...)
+	}
+	results, _, err := page(l.page, l.perPage)
+	if err != nil {
+		return err
+	}
+	return a.print(results, columns...)
+}
+
+// A string flag which may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+func taggings(names []string) qiita.Taggings {
+	tags := qiita.Taggings{}
+	for _, name := range names {
+		tags = append(tags, qiita.Tagging{Name: name})
+	}
+	return tags
+}
+
+// A body given by -body, or read from the file of -body-file, "-" being the standard input.
+type bodyFlags struct {
+	body string
+	file string
+}
+
+func bodyFlag(fs *flag.FlagSet) *bodyFlags {
+	b := &bodyFlags{}
+	fs.StringVar(&b.body, "body", "", "body in Markdown")
+	fs.StringVar(&b.file, "body-file", "", "read the body from `file`, or the standard input for -")
+	return b
+}
+
+// read returns the body and whether it is given.
+func (b *bodyFlags) read(a *app) (string, bool, error) {
+	switch {
+	case b.file == "":
+		return b.body, b.body != "", nil
+	case b.body != "":
+		return "", false, errors.New("-body and -body-file are exclusive")
+	case b.file == "-":
+		body, err := io.ReadAll(a.stdin)
+		return string(body), true, err
+	}
+	body, err := os.ReadFile(b.file)
+	return string(body), true, err
+}
+
+// visited returns the names of the flags set on the command line.
+func visited(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+func itemCommands() []*command {
+	return []*command{
+		leaf("list", "", 0, "List items, newest first", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			query := fs.String("query", "", "search `query`, e.g. \"tag:Go title:context\"")
+			user := fs.String("user", "", "list items of the `user`")
+			tag := fs.String("tag", "", "list items tagged with the `tag`")
+			mine := fs.Bool("mine", false, "list items of the authenticated user")
+			return func(ctx context.Context, a *app, args []string) error {
+				if countSet(*query != "", *user != "", *tag != "", *mine) > 1 {
+					return errors.New("-query, -user, -tag and -mine are exclusive")
+				}
+				if *query != "" {
+					q, err := qiita.ParseQuery(*query)
+					if err != nil {
+						return err
+					}
+					*query = q.String()
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				switch {
+				case *mine:
+					return list(a, l, itemColumns, func(page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+						return c.ListAuthenticatedUserItems(ctx, page, perPage)
+					}, c.AllAuthenticatedUserItems(ctx))
+				case *user != "":
+					return list(a, l, itemColumns, func(page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+						return c.ListUserItems(ctx, qiita.UserId(*user), page, perPage)
+					}, c.AllUserItems(ctx, qiita.UserId(*user)))
+				case *tag != "":
+					return list(a, l, itemColumns, func(page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+						return c.ListTaggedItems(ctx, qiita.TagId(*tag), page, perPage)
+					}, c.AllTaggedItems(ctx, qiita.TagId(*tag)))
+				}
+				return list(a, l, itemColumns, func(page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+					return c.ListItems(ctx, page, perPage, *query)
+				}, c.AllItems(ctx, *query))
+			}
+		}),
+		leaf("get", "<item id>", 1, "Show an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			item, err := c.GetItem(ctx, qiita.ItemId(args[0]))
+			if err != nil {
+				return err
+			}
+			return a.print(item, itemColumns...)
+		})),
+		leaf("create", "", 0, "Post an item", func(fs *flag.FlagSet) action {
+			title := fs.String("title", "", "title of the item")
+			var tags stringsFlag
+			fs.Var(&tags, "tag", "`tag` of the item, repeated for each tag")
+			b := bodyFlag(fs)
+			private := fs.Bool("private", false, "post a private item, only visible to those who know its URL")
+			return func(ctx context.Context, a *app, args []string) error {
+				body, _, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				if *title == "" || len(tags) == 0 {
+					return errUsage
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				item, err := c.CreateItem(ctx, qiita.Item{Title: *title, Body: body, Tags: taggings(tags), Private: *private})
+				if err != nil {
+					return err
+				}
+				return a.print(item, itemColumns...)
+			}
+		}),
+		leaf("update", "<item id>", 1, "Update the given fields of an item", func(fs *flag.FlagSet) action {
+			title := fs.String("title", "", "title of the item")
+			var tags stringsFlag
+			fs.Var(&tags, "tag", "`tag` of the item, repeated for each tag, replacing the current ones")
+			b := bodyFlag(fs)
+			private := fs.Bool("private", false, "make the item private")
+			return func(ctx context.Context, a *app, args []string) error {
+				body, hasBody, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				item, err := c.GetItem(ctx, qiita.ItemId(args[0]))
+				if err != nil {
+					return err
+				}
+				set := visited(fs)
+				if set["title"] {
+					item.Title = *title
+				}
+				if set["tag"] {
+					item.Tags = taggings(tags)
+				}
+				if hasBody {
+					item.Body = body
+				}
+				if set["private"] {
+					item.Private = *private
+				}
+				item, err = c.UpdateItem(ctx, *item)
+				if err != nil {
+					return err
+				}
+				return a.print(item, itemColumns...)
+			}
+		}),
+		leaf("delete", "<item id>", 1, "Delete an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.DeleteItem(ctx, qiita.ItemId(args[0]))
+		})),
+	}
+}
+
+func countSet(conditions ...bool) int {
+	n := 0
+	for _, c := range conditions {
+		if c {
+			n++
+		}
+	}
+	return n
+}
+
+func commentCommands() []*command {
+	return []*command{
+		leaf("list", "<item id>", 1, "List comments on an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			comments, err := c.ListComments(ctx, qiita.ItemId(args[0]))
+			if err != nil {
+				return err
+			}
+			return a.print(comments, commentColumns...)
+		})),
+		leaf("get", "<comment id>", 1, "Show a comment", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			comment, err := c.GetComment(ctx, qiita.CommentId(args[0]))
+			if err != nil {
+				return err
+			}
+			return a.print(comment, commentColumns...)
+		})),
+		leaf("post", "<item id>", 1, "Post a comment on an item", func(fs *flag.FlagSet) action {
+			b := bodyFlag(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				body, ok, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errUsage
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				comment, err := c.PostComment(ctx, qiita.ItemId(args[0]), qiita.Comment{Body: body})
+				if err != nil {
+					return err
+				}
+				return a.print(comment, commentColumns...)
+			}
+		}),
+		leaf("update", "<comment id>", 1, "Update a comment", func(fs *flag.FlagSet) action {
+			b := bodyFlag(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				body, ok, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errUsage
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				comment, err := c.UpdateComment(ctx, qiita.Comment{Id: qiita.CommentId(args[0]), Body: body})
+				if err != nil {
+					return err
+				}
+				return a.print(comment, commentColumns...)
+			}
+		}),
+		leaf("delete", "<comment id>", 1, "Delete a comment", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.DeleteComment(ctx, qiita.CommentId(args[0]))
+		})),
+	}
+}
+
+func tagCommands() []*command {
+	return []*command{
+		leaf("list", "", 0, "List tags", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			sort := fs.String("sort", "count", "`order` of tags: count or name")
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				return list(a, l, tagColumns, func(page, perPage uint) (*qiita.Tags, *qiita.Page, error) {
+					return c.ListTags(ctx, page, perPage, *sort)
+				}, c.AllTags(ctx, *sort))
+			}
+		}),
+		leaf("get", "<tag id>", 1, "Show a tag", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			tag, err := c.GetTag(ctx, qiita.TagId(args[0]))
+			if err != nil {
+				return err
+			}
+			return a.print(tag, tagColumns...)
+		})),
+		leaf("follow", "<tag id>", 1, "Follow a tag", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.FollowTag(ctx, qiita.TagId(args[0]))
+		})),
+		leaf("unfollow", "<tag id>", 1, "Unfollow a tag", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.UnfollowTag(ctx, qiita.TagId(args[0]))
+		})),
+		leaf("following", "<user id>", 1, "List tags a user follows", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				userId := qiita.UserId(args[0])
+				return list(a, l, tagColumns, func(page, perPage uint) (*qiita.Tags, *qiita.Page, error) {
+					return c.ListFollowingTags(ctx, userId, page, perPage)
+				}, c.AllFollowingTags(ctx, userId))
+			}
+		}),
+	}
+}
+
+func stockCommands() []*command {
+	return []*command{
+		leaf("add", "<item id>", 1, "Stock an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.StockItem(ctx, qiita.ItemId(args[0]))
+		})),
+		leaf("remove", "<item id>", 1, "Unstock an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.UnstockItem(ctx, qiita.ItemId(args[0]))
+		})),
+		leaf("check", "<item id>", 1, "Show whether you stocked an item", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			err = c.EnsureItemStock(ctx, qiita.ItemId(args[0]))
+			if err != nil && !errors.Is(err, qiita.ErrNotFound) {
+				return err
+			}
+			return a.print(struct {
+				ItemId  qiita.ItemId `json:"item_id"`
+				Stocked bool         `json:"stocked"`
+			}{qiita.ItemId(args[0]), err == nil})
+		})),
+		leaf("list", "<user id>", 1, "List items a user stocked", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				userId := qiita.UserId(args[0])
+				return list(a, l, itemColumns, func(page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+					return c.ListUserStocks(ctx, userId, page, perPage)
+				}, c.AllUserStocks(ctx, userId))
+			}
+		}),
+	}
+}
+
+func userCommands() []*command {
+	return []*command{
+		leaf("list", "", 0, "List users", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				return list(a, l, userColumns, func(page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+					return c.ListUsers(ctx, page, perPage)
+				}, c.AllUsers(ctx))
+			}
+		}),
+		leaf("get", "<user id>", 1, "Show a user", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			user, err := c.GetUser(ctx, qiita.UserId(args[0]))
+			if err != nil {
+				return err
+			}
+			return a.print(user, userColumns...)
+		})),
+		leaf("me", "", 0, "Show the authenticated user", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			user, err := c.GetAuthenticatedUser(ctx)
+			if err != nil {
+				return err
+			}
+			return a.print(user, userColumns...)
+		})),
+		leaf("followers", "<user id>", 1, "List followers of a user", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				userId := qiita.UserId(args[0])
+				return list(a, l, userColumns, func(page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+					return c.ListFollowers(ctx, userId, page, perPage)
+				}, c.AllFollowers(ctx, userId))
+			}
+		}),
+		leaf("followees", "<user id>", 1, "List users a user follows", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				userId := qiita.UserId(args[0])
+				return list(a, l, userColumns, func(page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+					return c.ListFollowees(ctx, userId, page, perPage)
+				}, c.AllFollowees(ctx, userId))
+			}
+		}),
+		leaf("follow", "<user id>", 1, "Follow a user", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.FollowUser(ctx, qiita.UserId(args[0]))
+		})),
+		leaf("unfollow", "<user id>", 1, "Unfollow a user", noFlags(func(ctx context.Context, a *app, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.UnfollowUser(ctx, qiita.UserId(args[0]))
+		})),
+	}
+}
+
+// parseId parses the numeric id of a project or a template.
+func parseId(s string) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 0)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("invalid id %q", s)
+	}
+	return uint(n), nil
+}
+
+func projectCommands() []*command {
+	return []*command{
+		leaf("list", "", 0, "List projects", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				return list(a, l, projectColumns, func(page, perPage uint) (*qiita.Projects, *qiita.Page, error) {
+					return c.ListProjects(ctx, page, perPage)
+				}, c.AllProjects(ctx))
+			}
+		}),
+		leaf("get", "<project id>", 1, "Show a project", noFlags(func(ctx context.Context, a *app, args []string) error {
+			id, err := parseId(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			project, err := c.GetProject(ctx, qiita.ProjectId(id))
+			if err != nil {
+				return err
+			}
+			return a.print(project, projectColumns...)
+		})),
+		leaf("create", "", 0, "Create a project", func(fs *flag.FlagSet) action {
+			name := fs.String("name", "", "name of the project")
+			b := bodyFlag(fs)
+			archived := fs.Bool("archived", false, "create an archived project")
+			return func(ctx context.Context, a *app, args []string) error {
+				body, _, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				if *name == "" {
+					return errUsage
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				project, err := c.CreateProject(ctx, qiita.Project{Name: *name, Body: body, Archived: *archived})
+				if err != nil {
+					return err
+				}
+				return a.print(project, projectColumns...)
+			}
+		}),
+		leaf("update", "<project id>", 1, "Update the given fields of a project", func(fs *flag.FlagSet) action {
+			name := fs.String("name", "", "name of the project")
+			b := bodyFlag(fs)
+			archived := fs.Bool("archived", false, "archive the project")
+			return func(ctx context.Context, a *app, args []string) error {
+				id, err := parseId(args[0])
+				if err != nil {
+					return err
+				}
+				body, hasBody, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				project, err := c.GetProject(ctx, qiita.ProjectId(id))
+				if err != nil {
+					return err
+				}
+				set := visited(fs)
+				if set["name"] {
+					project.Name = *name
+				}
+				if hasBody {
+					project.Body = body
+				}
+				if set["archived"] {
+					project.Archived = *archived
+				}
+				project, err = c.UpdateProject(ctx, *project)
+				if err != nil {
+					return err
+				}
+				return a.print(project, projectColumns...)
+			}
+		}),
+		leaf("delete", "<project id>", 1, "Delete a project", noFlags(func(ctx context.Context, a *app, args []string) error {
+			id, err := parseId(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.DeleteProject(ctx, qiita.ProjectId(id))
+		})),
+	}
+}
+
+func templateCommands() []*command {
+	return []*command{
+		leaf("list", "", 0, "List templates", func(fs *flag.FlagSet) action {
+			l := pagination(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				return list(a, l, templateColumns, func(page, perPage uint) (*qiita.Templates, *qiita.Page, error) {
+					return c.ListTemplates(ctx, page, perPage)
+				}, c.AllTemplates(ctx))
+			}
+		}),
+		leaf("get", "<template id>", 1, "Show a template", noFlags(func(ctx context.Context, a *app, args []string) error {
+			id, err := parseId(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			template, err := c.GetTemplate(ctx, qiita.TemplateId(id))
+			if err != nil {
+				return err
+			}
+			return a.print(template, templateColumns...)
+		})),
+		leaf("create", "", 0, "Create a template", func(fs *flag.FlagSet) action {
+			name := fs.String("name", "", "name of the template")
+			title := fs.String("title", "", "title of items generated from the template")
+			var tags stringsFlag
+			fs.Var(&tags, "tag", "`tag` of generated items, repeated for each tag")
+			b := bodyFlag(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				body, _, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				if *name == "" {
+					return errUsage
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				t := taggings(tags)
+				template, err := c.CreateTemplate(ctx, qiita.Template{Name: *name, Title: *title, Body: body, Tags: &t})
+				if err != nil {
+					return err
+				}
+				return a.print(template, templateColumns...)
+			}
+		}),
+		leaf("update", "<template id>", 1, "Update the given fields of a template", func(fs *flag.FlagSet) action {
+			name := fs.String("name", "", "name of the template")
+			title := fs.String("title", "", "title of items generated from the template")
+			var tags stringsFlag
+			fs.Var(&tags, "tag", "`tag` of generated items, repeated for each tag, replacing the current ones")
+			b := bodyFlag(fs)
+			return func(ctx context.Context, a *app, args []string) error {
+				id, err := parseId(args[0])
+				if err != nil {
+					return err
+				}
+				body, hasBody, err := b.read(a)
+				if err != nil {
+					return err
+				}
+				c, err := a.client()
+				if err != nil {
+					return err
+				}
+				template, err := c.GetTemplate(ctx, qiita.TemplateId(id))
+				if err != nil {
+					return err
+				}
+				set := visited(fs)
+				if set["name"] {
+					template.Name = *name
+				}
+				if set["title"] {
+					template.Title = *title
+				}
+				if set["tag"] {
+					t := taggings(tags)
+					template.Tags = &t
+				}
+				if hasBody {
+					template.Body = body
+				}
+				template, err = c.UpdateTemplate(ctx, *template)
+				if err != nil {
+					return err
+				}
+				return a.print(template, templateColumns...)
+			}
+		}),
+		leaf("delete", "<template id>", 1, "Delete a template", noFlags(func(ctx context.Context, a *app, args []string) error {
+			id, err := parseId(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			return c.DeleteTemplate(ctx, qiita.TemplateId(id))
+		})),
+	}
+}
+
+func syncCommand(fs *flag.FlagSet) action {
+	dryRun := fs.Bool("dry-run", false, "print the plan without changing anything")
+	force := fs.Bool("force", false, "overwrite items edited or deleted on Qiita since the last synchronization")
+	return func(ctx context.Context, a *app, args []string) error {
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		s := qiitasync.NewSyncer(c, args[0]).WithForce(*force)
+		plan, err := s.Plan(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(a.stdout, plan)
+		if *dryRun {
+			return nil
+		}
+		if err := s.Apply(ctx, plan); err != nil {
+			return err
+		}
+		conflicts := 0
+		for _, c := range plan {
+			if c.Action == qiitasync.Conflict {
+				conflicts++
+			}
+		}
+		if conflicts > 0 {
+			return fmt.Errorf("%d conflicts, run with -force to overwrite them", conflicts)
+		}
+		return nil
+	}
+}
+
+func exportCommand(fs *flag.FlagSet) action {
+	reserve := fs.Uint("reserve", 0, "requests of each rate limit window to leave to other uses of the token")
+	return func(ctx context.Context, a *app, args []string) error {
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		m, err := qiitaarchive.NewExporter(c, args[0]).WithReserve(*reserve).Export(ctx)
+		if err != nil {
+			return err
+		}
+		return a.print(m, manifestColumns...)
+	}
+}
+
+func importCommand(fs *flag.FlagSet) action {
+	reserve := fs.Uint("reserve", 0, "requests of each rate limit window to leave to other uses of the token")
+	mapping := fs.String("mapping", "", "file of the ids of the migrated content (default <dir>/mapping.json)")
+	return func(ctx context.Context, a *app, args []string) error {
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		i := qiitaarchive.NewImporter(c, args[0]).WithReserve(*reserve)
+		if *mapping != "" {
+			i.WithMapping(*mapping)
+		}
+		report, err := i.Import(ctx)
+		if report != nil {
+			if err := a.print(report, reportColumns...); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
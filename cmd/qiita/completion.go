@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// completion prints the completion script of a shell, generated from the commands and their flags.
+func completion(ctx context.Context, a *app, args []string) error {
+	var script string
+	switch args[0] {
+	case "bash":
+		script = bashCompletion(commands())
+	case "zsh":
+		script = "#compdef qiita\n\nautoload -U +X bashcompinit && bashcompinit\n\n" + bashCompletion(commands())
+	case "fish":
+		script = fishCompletion(commands())
+	default:
+		return errUsage
+	}
+	_, err := fmt.Fprint(a.stdout, script)
+	return err
+}
+
+// walk calls f for c and each of its descendants, with the names of the commands leading to them.
+func walk(c *command, path []string, f func(c *command, path []string)) {
+	f(c, path)
+	for _, sub := range c.commands {
+		walk(sub, append(slices.Clip(path), sub.name), f)
+	}
+}
+
+// flagSet returns the flags of a leaf command, or the global flags of a group.
+func flagSet(c *command) *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	(&app{}).globalFlags(fs)
+	if c.flags != nil {
+		c.flags(fs)
+	}
+	return fs
+}
+
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// choices returns the values of positional arguments given as alternatives, as "bash|zsh|fish".
+func choices(c *command) []string {
+	if c.args == "" || strings.Contains(c.args, "<") {
+		return nil
+	}
+	return strings.Split(c.args, "|")
+}
+
+func bashCompletion(root *command) string {
+	var valueFlags []string
+	var cases strings.Builder
+	walk(root, nil, func(c *command, path []string) {
+		var words []string
+		name := strings.Join(path, " ")
+		pattern := fmt.Sprintf("%q", name)
+		if c.flags == nil {
+			for _, sub := range c.commands {
+				words = append(words, sub.name)
+			}
+			if len(path) == 0 {
+				words = append(words, "help")
+			}
+		} else {
+			// Positional arguments follow a leaf command.
+			pattern = fmt.Sprintf("%q|%q*", name, name+" ")
+			words = append(words, choices(c)...)
+		}
+		flagSet(c).VisitAll(func(f *flag.Flag) {
+			words = append(words, "-"+f.Name)
+			if !isBool(f) && !slices.Contains(valueFlags, f.Name) {
+				valueFlags = append(valueFlags, f.Name)
+			}
+		})
+		fmt.Fprintf(&cases, "\t%s) words=%q ;;\n", pattern, strings.Join(words, " "))
+	})
+	slices.Sort(valueFlags)
+	var values []string
+	for _, name := range valueFlags {
+		values = append(values, "-"+name, "--"+name)
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for qiita, generated by \"qiita completion bash\".\n\n")
+	b.WriteString("_qiita() {\n")
+	b.WriteString("\tlocal cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]} path= words= i\n")
+	b.WriteString("\tfor ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("\t\tcase ${COMP_WORDS[i]} in\n")
+	fmt.Fprintf(&b, "\t\t%s) ((i++)) ;;\n", strings.Join(values, "|"))
+	b.WriteString("\t\t-*) ;;\n")
+	b.WriteString("\t\t*) path=${path:+$path }${COMP_WORDS[i]} ;;\n")
+	b.WriteString("\t\tesac\n")
+	b.WriteString("\tdone\n")
+	b.WriteString("\tcase $prev in\n")
+	b.WriteString("\t-o|--o|-output|--output)\n")
+	b.WriteString("\t\tCOMPREPLY=($(compgen -W \"json table yaml\" -- \"$cur\"))\n")
+	b.WriteString("\t\treturn ;;\n")
+	fmt.Fprintf(&b, "\t%s) return ;;\n", strings.Join(values, "|"))
+	b.WriteString("\tesac\n")
+	b.WriteString("\tcase $path in\n")
+	b.WriteString(cases.String())
+	b.WriteString("\tesac\n")
+	b.WriteString("\tCOMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("}\n\n")
+	b.WriteString("complete -F _qiita qiita\n")
+	return b.String()
+}
+
+func fishCompletion(root *command) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for qiita, generated by \"qiita completion fish\".\n\n")
+	b.WriteString("complete -c qiita -f\n")
+	flagSet(root).VisitAll(func(f *flag.Flag) {
+		fmt.Fprintf(&b, "complete -c qiita -o %s%s -d %s\n", f.Name, fishValue(f), fishQuote(usage(f)))
+	})
+	walk(root, nil, func(c *command, path []string) {
+		if len(path) == 0 {
+			return
+		}
+		parent, name := path[:len(path)-1], path[len(path)-1]
+		fmt.Fprintf(&b, "complete -c qiita -n %s -a %s -d %s\n", fishQuote(fishCondition(root, parent)), name, fishQuote(c.summary))
+		if c.flags == nil {
+			return
+		}
+		condition := fishQuote(fishSeen(path))
+		if words := choices(c); len(words) > 0 {
+			fmt.Fprintf(&b, "complete -c qiita -n %s -a %s\n", condition, fishQuote(strings.Join(words, " ")))
+		}
+		global := flagSet(root)
+		flagSet(c).VisitAll(func(f *flag.Flag) {
+			if global.Lookup(f.Name) == nil {
+				fmt.Fprintf(&b, "complete -c qiita -n %s -o %s%s -d %s\n", condition, f.Name, fishValue(f), fishQuote(usage(f)))
+			}
+		})
+	})
+	return b.String()
+}
+
+// fishCondition is true while the subcommands of the command at path are to be completed.
+func fishCondition(root *command, path []string) string {
+	c := root
+	for _, name := range path {
+		c = c.find(name)
+	}
+	var names []string
+	for _, sub := range c.commands {
+		names = append(names, sub.name)
+	}
+	if len(path) == 0 {
+		return "__fish_use_subcommand"
+	}
+	return fishSeen(path) + "; and not __fish_seen_subcommand_from " + strings.Join(names, " ")
+}
+
+func fishSeen(path []string) string {
+	conditions := make([]string, len(path))
+	for i, name := range path {
+		conditions[i] = "__fish_seen_subcommand_from " + name
+	}
+	return strings.Join(conditions, "; and ")
+}
+
+func fishValue(f *flag.Flag) string {
+	switch {
+	case f.Name == "o" || f.Name == "output":
+		return " -x -a 'json table yaml'"
+	case isBool(f):
+		return ""
+	}
+	return " -r"
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func usage(f *flag.Flag) string {
+	_, usage := flag.UnquoteUsage(f)
+	return usage
+}
@@ -0,0 +1,168 @@
+// Command qiita is a command-line client of Qiita API v2.
+//
+// Usage:
+//
+//	qiita [flags] <command> <subcommand> [flags] [arguments]
+//
+// The access token is taken from the -token flag, the QIITA_ACCESS_TOKEN environment variable or
+// the "token" of the config file, in this order. The config file is $QIITA_CONFIG, or qiita/config.json
+// in the user config directory such as ~/.config/qiita/config.json:
+//
+//	{"token": "<qiita access token>", "team": "<team id>"}
+//
+// The API is public Qiita, or a Qiita:Team or another endpoint chosen by -team or -endpoint,
+// QIITA_TEAM or QIITA_ENDPOINT, or the "team" or "endpoint" of the config file, in this order.
+//
+// Results are written as JSON by default, or as a table or YAML with -o table or -o yaml.
+// Run "qiita help" for the list of commands, and "qiita completion bash|zsh|fish" for a shell
+// completion script.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// Returned from an action when its command line is invalid, to print the usage of the command.
+var errUsage = errors.New("usage")
+
+// A command, either a group of subcommands or a leaf with an action.
+type command struct {
+	name    string
+	args    string // positional arguments in usage, e.g. "<item id>"
+	nargs   int    // number of positional arguments, or -1 for any
+	summary string
+
+	commands []*command
+
+	// flags registers the flags of a leaf command and returns its action.
+	flags func(fs *flag.FlagSet) action
+}
+
+type action func(ctx context.Context, a *app, args []string) error
+
+func (c *command) find(name string) *command {
+	for _, sub := range c.commands {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// run runs the command line args and returns the exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	root := commands()
+	fs := flag.NewFlagSet("qiita", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a.globalFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(a.stderr, "qiita: %v\n", err)
+		a.usage(root, nil)
+		return 2
+	}
+	args = fs.Args()
+
+	c, path := root, []string{}
+	for c.flags == nil {
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			a.usage(c, path)
+			if len(args) == 0 {
+				return 2
+			}
+			return 0
+		}
+		sub := c.find(args[0])
+		if sub == nil {
+			fmt.Fprintf(a.stderr, "qiita: unknown command %q\n", strings.Join(append(path, args[0]), " "))
+			a.usage(c, path)
+			return 2
+		}
+		c, path, args = sub, append(path, args[0]), args[1:]
+	}
+
+	fs = flag.NewFlagSet("qiita "+strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a.globalFlags(fs)
+	act := c.flags(fs)
+	positional, err := parseInterspersed(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		a.leafUsage(c, path, fs)
+		return 0
+	}
+	if err == nil && c.nargs >= 0 && len(positional) != c.nargs {
+		err = fmt.Errorf("%d arguments given, want %d", len(positional), c.nargs)
+	}
+	if err != nil {
+		fmt.Fprintf(a.stderr, "qiita: %v\n", err)
+		a.leafUsage(c, path, fs)
+		return 2
+	}
+	if err := act(ctx, a, positional); err != nil {
+		if errors.Is(err, errUsage) {
+			a.leafUsage(c, path, fs)
+			return 2
+		}
+		fmt.Fprintf(a.stderr, "qiita: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseInterspersed parses flags of fs placed anywhere among positional arguments, as in
+// "qiita items get <item id> -o yaml", and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func (a *app) usage(c *command, path []string) {
+	w := a.stderr
+	name := strings.TrimSpace("qiita " + strings.Join(path, " "))
+	fmt.Fprintf(w, "Usage: %s <command> [flags] [arguments]\n\nCommands:\n", name)
+	for _, sub := range c.commands {
+		fmt.Fprintf(w, "  %-12s %s\n", sub.name, sub.summary)
+	}
+	if len(path) == 0 {
+		fmt.Fprintf(w, "  %-12s %s\n", "help", "Show help of a command")
+		fmt.Fprint(w, "\nFlags available to every command:\n")
+		fs := flag.NewFlagSet("", flag.ContinueOnError)
+		a.globalFlags(fs)
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+	}
+}
+
+func (a *app) leafUsage(c *command, path []string, fs *flag.FlagSet) {
+	w := a.stderr
+	usage := strings.TrimSpace(fmt.Sprintf("qiita %s [flags] %s", strings.Join(path, " "), c.args))
+	fmt.Fprintf(w, "Usage: %s\n\n%s\n\nFlags:\n", usage, c.summary)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitatest"
+)
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// run runs a command line against the environment variables of env, where QIITA_CONFIG defaults to
+// a missing file.
+func run(t *testing.T, env map[string]string, stdin string, args ...string) result {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string {
+			if v, ok := env[key]; ok {
+				return v
+			}
+			if key == "QIITA_CONFIG" {
+				return filepath.Join(t.TempDir(), "config.json")
+			}
+			return ""
+		},
+	}
+	code := a.run(context.Background(), args)
+	return result{code, stdout.String(), stderr.String()}
+}
+
+func setup(t *testing.T) (*qiitatest.Server, map[string]string) {
+	s := qiitatest.NewServer()
+	t.Cleanup(s.Close)
+	token := s.AddUser(qiita.User{Id: "qiita", Name: "Qiita"})
+	return s, map[string]string{"QIITA_ACCESS_TOKEN": token, "QIITA_ENDPOINT": s.URL}
+}
+
+func TestItems(t *testing.T) {
+	_, env := setup(t)
+
+	r := run(t, env, "# Example\n\nbody\n", "items", "create", "-title", "Example", "-tag", "Go", "-tag", "CLI", "-body-file", "-")
+	if r.code != 0 {
+		t.Fatalf("create: %+v", r)
+	}
+	var item qiita.Item
+	if err := json.Unmarshal([]byte(r.stdout), &item); err != nil {
+		t.Fatal(err)
+	}
+	if item.Id == "" || item.Body != "# Example\n\nbody\n" || len(item.Tags) != 2 {
+		t.Errorf("created %+v", item)
+	}
+	id := string(item.Id)
+
+	r = run(t, env, "", "items", "get", id, "-o", "yaml")
+	if r.code != 0 || !strings.Contains(r.stdout, "title: Example\n") || !strings.Contains(r.stdout, "body: |\n  # Example\n\n  body\n") ||
+		!strings.Contains(r.stdout, "tags:\n  - name: Go\n  - name: CLI\n") {
+		t.Errorf("get -o yaml: %+v", r)
+	}
+
+	r = run(t, env, "", "-o", "table", "items", "list", "-user", "qiita")
+	if r.code != 0 {
+		t.Fatalf("list: %+v", r)
+	}
+	lines := strings.Split(strings.TrimSuffix(r.stdout, "\n"), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[0] != "ID" || !strings.HasPrefix(lines[1], id) || !strings.Contains(lines[1], "Go,CLI") {
+		t.Errorf("list -o table: %q", r.stdout)
+	}
+	if r := run(t, env, "", "items", "list", "-query", "tag:Go OR"); r.code != 1 || !strings.Contains(r.stderr, "OR at the end") {
+		t.Errorf("list -query: %+v", r)
+	}
+
+	r = run(t, env, "", "items", "update", id, "-title", "Updated")
+	if r.code != 0 {
+		t.Fatalf("update: %+v", r)
+	}
+	json.Unmarshal([]byte(r.stdout), &item)
+	if item.Title != "Updated" || item.Body != "# Example\n\nbody\n" || len(item.Tags) != 2 {
+		t.Errorf("updated %+v", item)
+	}
+
+	for _, args := range [][]string{
+		{"stock", "add", id},
+		{"tags", "follow", "Go"},
+		{"comments", "post", id, "-body", "LGTM"},
+	} {
+		if r := run(t, env, "", args...); r.code != 0 {
+			t.Errorf("%v: %+v", args, r)
+		}
+	}
+	if r := run(t, env, "", "stock", "check", id); r.stdout != "{\n  \"item_id\": \""+id+"\",\n  \"stocked\": true\n}\n" {
+		t.Errorf("stock check: %+v", r)
+	}
+	if r := run(t, env, "", "-o", "table", "tags", "following", "qiita"); !strings.Contains(r.stdout, "Go ") {
+		t.Errorf("tags following: %+v", r)
+	}
+	if r := run(t, env, "", "-o", "table", "comments", "list", id); !strings.Contains(r.stdout, "LGTM") {
+		t.Errorf("comments list: %+v", r)
+	}
+
+	if r := run(t, env, "", "items", "delete", id); r.code != 0 || r.stdout != "" {
+		t.Errorf("delete: %+v", r)
+	}
+	if r := run(t, env, "", "items", "get", id); r.code != 1 || !strings.Contains(r.stderr, "404") {
+		t.Errorf("get deleted: %+v", r)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	_, env := setup(t)
+
+	r := run(t, env, "", "projects", "create", "-name", "Project", "-body", "# Project")
+	if r.code != 0 {
+		t.Fatalf("create: %+v", r)
+	}
+	r = run(t, env, "", "projects", "update", "1", "-archived", "-o", "yaml")
+	if r.code != 0 || !strings.Contains(r.stdout, "archived: true\n") || !strings.Contains(r.stdout, "body: \"# Project\"\n") {
+		t.Errorf("update: %+v", r)
+	}
+	if r := run(t, env, "", "projects", "get", "x"); r.code != 1 || !strings.Contains(r.stderr, `invalid id "x"`) {
+		t.Errorf("get x: %+v", r)
+	}
+}
+
+func TestSync(t *testing.T) {
+	_, env := setup(t)
+	dir := t.TempDir()
+	name := filepath.Join(dir, "example.md")
+	if err := os.WriteFile(name, []byte("---\ntitle: Example\ntags: [Go]\n---\nbody\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := run(t, env, "", "sync", "-dry-run", dir); r.code != 0 || r.stdout != "create  example.md  \"Example\"\n" {
+		t.Errorf("sync -dry-run: %+v", r)
+	}
+	if r := run(t, env, "", "sync", dir); r.code != 0 {
+		t.Errorf("sync: %+v", r)
+	}
+	if b, _ := os.ReadFile(name); !strings.Contains(string(b), "\nid: ") {
+		t.Errorf("example.md =\n%s", b)
+	}
+	if r := run(t, env, "", "sync", dir); r.code != 0 || !strings.HasPrefix(r.stdout, "unchanged  example.md  ") {
+		t.Errorf("sync again: %+v", r)
+	}
+}
+
+func TestExport(t *testing.T) {
+	_, env := setup(t)
+	dir := t.TempDir()
+	if r := run(t, env, "", "items", "create", "-title", "Example", "-tag", "Go", "-body", "body"); r.code != 0 {
+		t.Fatalf("items create: %+v", r)
+	}
+
+	r := run(t, env, "", "-o", "table", "export", dir)
+	if r.code != 0 || !strings.Contains(r.stdout, "\nCOUNTS.ITEMS     1\nCOUNTS.COMMENTS  0\n") {
+		t.Errorf("export: %+v", r)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "items.jsonl")); err != nil || !strings.Contains(string(b), `"title":"Example"`) {
+		t.Errorf("items.jsonl = %s, %v", b, err)
+	}
+
+	r = run(t, env, "", "-o", "table", "import", "-mapping", filepath.Join(t.TempDir(), "mapping.json"), dir)
+	if r.code != 0 || !strings.HasPrefix(r.stdout, "CREATED.ITEMS       1\nCREATED.COMMENTS    0\n") {
+		t.Errorf("import: %+v", r)
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	s, env := setup(t)
+	config := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(config, []byte(`{"token": "`+env["QIITA_ACCESS_TOKEN"]+`", "endpoint": "`+s.URL+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := run(t, map[string]string{"QIITA_CONFIG": config}, "", "users", "me", "-o", "table")
+	if r.code != 0 || r.stdout != "ID               qiita\nNAME             Qiita\nITEMS_COUNT      0\nFOLLOWERS_COUNT  0\n" {
+		t.Errorf("users me: %+v", r)
+	}
+	// The flag takes precedence over the config file.
+	if r := run(t, map[string]string{"QIITA_CONFIG": config}, "", "users", "me", "-token", "unknown"); r.code != 1 || !strings.Contains(r.stderr, "401") {
+		t.Errorf("users me -token: %+v", r)
+	}
+}
+
+func TestTeamAndEndpoint(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(config, []byte(`{"team": "file", "endpoint": "https://file.example.com"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		team, endpoint string
+		env            map[string]string
+		want           string // host, or an error
+	}{
+		{"flag", "", map[string]string{"QIITA_ENDPOINT": "https://env.example.com"}, "flag.qiita.com"},
+		{"", "https://flag.example.com", map[string]string{"QIITA_TEAM": "env"}, "flag.example.com"},
+		{"", "", map[string]string{"QIITA_TEAM": "env"}, "env.qiita.com"},
+		{"", "", map[string]string{"QIITA_ENDPOINT": "https://env.example.com"}, "env.example.com"},
+		{"", "", map[string]string{}, "qiita.com"},
+		{"flag", "", map[string]string{"QIITA_CONFIG": config}, "flag.qiita.com"},
+		{"", "", map[string]string{"QIITA_CONFIG": config}, `"team" and "endpoint" of the config file cannot be used together`},
+		{"flag", "https://flag.example.com", map[string]string{}, "-team and -endpoint cannot be used together"},
+	} {
+		a := &app{team: tt.team, endpoint: tt.endpoint, getenv: func(key string) string {
+			if key == "QIITA_CONFIG" && tt.env[key] == "" {
+				return filepath.Join(t.TempDir(), "config.json")
+			}
+			return tt.env[key]
+		}}
+		c, err := a.client()
+		var got string
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = c.URL.Host
+		}
+		if got != tt.want {
+			t.Errorf("client() with -team %q -endpoint %q and %v = %s, want %s", tt.team, tt.endpoint, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	for _, tt := range []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{nil, 2, "Usage: qiita <command>"},
+		{[]string{"help"}, 0, "Usage: qiita <command>"},
+		{[]string{"items", "help"}, 0, "Usage: qiita items <command>"},
+		{[]string{"items", "get", "-h"}, 0, "Usage: qiita items get [flags] <item id>"},
+		{[]string{"unknown"}, 2, `unknown command "unknown"`},
+		{[]string{"items", "unknown"}, 2, `unknown command "items unknown"`},
+		{[]string{"items", "get"}, 2, "0 arguments given, want 1"},
+		{[]string{"items", "create", "-title", "Example"}, 2, "Usage: qiita items create"},
+		{[]string{"-o", "xml", "users", "me"}, 2, `unknown output format "xml"`},
+		{[]string{"completion", "powershell"}, 2, "Usage: qiita completion"},
+	} {
+		if r := run(t, nil, "", tt.args...); r.code != tt.code || !strings.Contains(r.stderr, tt.stderr) {
+			t.Errorf("%v: %+v, want exit code %d and %q", tt.args, r, tt.code, tt.stderr)
+		}
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for shell, want := range map[string][]string{
+		"bash": {"complete -F _qiita qiita\n", "\t\"items\") words=\"list get create update delete ", "\t\"items list\"|\"items list \"*) words=\"-all "},
+		"zsh":  {"#compdef qiita\n", "bashcompinit", "complete -F _qiita qiita\n"},
+		"fish": {"complete -c qiita -n '__fish_use_subcommand' -a stock -d 'Stock items'\n", "complete -c qiita -o o -x -a 'json table yaml'"},
+	} {
+		r := run(t, nil, "", "completion", shell)
+		for _, w := range want {
+			if r.code != 0 || !strings.Contains(r.stdout, w) {
+				t.Errorf("completion %s: %+v, want %q", shell, r, w)
+			}
+		}
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	(&app{}).globalFlags(fs)
+	positional, err := parseInterspersed(fs, []string{"a", "-o", "yaml", "b", "--", "-c"})
+	if err != nil || strings.Join(positional, " ") != "a b -c" || fs.Lookup("o").Value.String() != "yaml" {
+		t.Errorf("positional %q, err %v, -o %s", positional, err, fs.Lookup("o").Value)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// print writes v in the output format. A table shows the given columns, which are paths of JSON
+// fields such as "user.id".
+func (a *app) print(v any, columns ...string) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	switch a.output {
+	case formatTable:
+		tree, err := decodeOrdered(b)
+		if err != nil {
+			return err
+		}
+		return writeTable(a.stdout, tree, columns)
+	case formatYAML:
+		tree, err := decodeOrdered(b)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		writeYAML(&buf, tree, 0)
+		_, err = a.stdout.Write(buf.Bytes())
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err = a.stdout.Write(buf.Bytes())
+	return err
+}
+
+// A JSON object keeping the order of its members, unlike map[string]any.
+type object []member
+
+type member struct {
+	key   string
+	value any
+}
+
+func (o object) get(key string) (any, bool) {
+	for _, m := range o {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// decodeOrdered decodes JSON into an object, []any, string, json.Number, bool or nil.
+func decodeOrdered(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('{'):
+		o := object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, member{key.(string), v})
+		}
+		_, err := dec.Token()
+		return o, err
+	case json.Delim('['):
+		l := []any{}
+		for dec.More() {
+			v, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		_, err := dec.Token()
+		return l, err
+	}
+	return t, nil
+}
+
+// Cells longer than this are truncated with an ellipsis.
+const maxCellWidth = 60
+
+// writeTable writes a list as rows, or a single object as one row per column.
+func writeTable(w io.Writer, v any, columns []string) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	switch v := v.(type) {
+	case []any:
+		if len(columns) == 0 && len(v) > 0 {
+			columns = scalarKeys(v[0])
+		}
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = strings.ToUpper(c)
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range v {
+			cells := make([]string, len(columns))
+			for i, c := range columns {
+				cells[i] = cell(lookup(row, c))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	case object:
+		if len(columns) == 0 {
+			columns = scalarKeys(v)
+		}
+		for _, c := range columns {
+			fmt.Fprintf(tw, "%s\t%s\n", strings.ToUpper(c), cell(lookup(v, c)))
+		}
+	case nil:
+	default:
+		fmt.Fprintln(tw, cell(v))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// Empty cells at the end of rows are padded.
+	for line := range strings.Lines(buf.String()) {
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scalarKeys returns the keys of the members of v which are neither objects nor lists.
+func scalarKeys(v any) []string {
+	o, _ := v.(object)
+	var keys []string
+	for _, m := range o {
+		switch m.value.(type) {
+		case object, []any:
+		default:
+			keys = append(keys, m.key)
+		}
+	}
+	return keys
+}
+
+// lookup follows a dotted path of keys in v. A list on the way is mapped element-wise, so that
+// "tags.name" of an item results in the names of its tags.
+func lookup(v any, path string) any {
+	key, rest, _ := strings.Cut(path, ".")
+	switch v := v.(type) {
+	case object:
+		child, ok := v.get(key)
+		if !ok {
+			return nil
+		}
+		if rest == "" {
+			return child
+		}
+		return lookup(child, rest)
+	case []any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = lookup(e, path)
+		}
+		return l
+	}
+	return nil
+}
+
+// cell formats v in a single line of a table.
+func cell(v any) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+	case string:
+		s = v
+	case []any:
+		cells := make([]string, len(v))
+		for i, e := range v {
+			cells[i] = cell(e)
+		}
+		s = strings.Join(cells, ",")
+	case object:
+		s = "{...}"
+	default:
+		s = fmt.Sprint(v)
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestYAML(t *testing.T) {
+	for _, tt := range []struct {
+		json string
+		yaml string
+	}{
+		{`null`, "null\n"},
+		{`{}`, "{}\n"},
+		{`[]`, "[]\n"},
+		{`{"id": 1, "ok": true, "ratio": 0.5}`, "id: 1\nok: true\nratio: 0.5\n"},
+		{`{"a": "", "b": "true", "c": "123", "d": "null", "e": "# h", "f": "a: b", "g": " x", "h": "- x", "i": "日本語"}`,
+			"a: \"\"\nb: \"true\"\nc: \"123\"\nd: \"null\"\ne: \"# h\"\nf: \"a: b\"\ng: \" x\"\nh: \"- x\"\ni: 日本語\n"},
+		{`{"body": "a\nb\n", "rendered": "a\n\n  b", "tab": "a\tb\n"}`,
+			"body: |\n  a\n  b\nrendered: |-\n  a\n\n    b\ntab: \"a\\tb\\n\"\n"},
+		{`{"user": {"id": "qiita", "tags": []}, "items": [{"id": 1, "tags": [{"name": "Go"}]}, [1, 2], "x\ny"]}`,
+			"user:\n  id: qiita\n  tags: []\nitems:\n  - id: 1\n    tags:\n      - name: Go\n  - - 1\n    - 2\n  - |-\n    x\n    y\n"},
+	} {
+		v, err := decodeOrdered([]byte(tt.json))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		writeYAML(&buf, v, 0)
+		if buf.String() != tt.yaml {
+			t.Errorf("%s:\n%s\nwant:\n%s", tt.json, buf.String(), tt.yaml)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	v, err := decodeOrdered([]byte(`[
+		{"id": "a", "title": "line\nbreak", "user": {"id": "qiita"}, "tags": [{"name": "Go"}, {"name": "CLI"}]},
+		{"id": "b", "title": "` + string(bytes.Repeat([]byte("x"), 70)) + `", "user": null, "tags": []}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeTable(&buf, v, []string{"id", "title", "user.id", "tags.name"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "ID  TITLE                                                         USER.ID  TAGS.NAME\n" +
+		"a   line break                                                    qiita    Go,CLI\n" +
+		"b   " + string(bytes.Repeat([]byte("x"), 59)) + "…\n"
+	if buf.String() != want {
+		t.Errorf("table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/ktsujichan/qiita-sdk-go/internal/yamlscalar"
+)
+
+// writeYAML writes a value decoded by decodeOrdered as a YAML document, with its nested collections
+// indented by two more spaces than indent.
+func writeYAML(buf *bytes.Buffer, v any, indent int) {
+	switch v := v.(type) {
+	case object:
+		if len(v) == 0 {
+			buf.WriteString("{}\n")
+			return
+		}
+		for i, m := range v {
+			if i > 0 {
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+			buf.WriteString(yamlscalar.String(m.key))
+			buf.WriteByte(':')
+			writeYAMLChild(buf, m.value, indent)
+		}
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]\n")
+			return
+		}
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+			buf.WriteString("- ")
+			if _, ok := e.(string); ok {
+				// A block scalar is indented relative to the dash.
+				writeYAML(buf, e, indent)
+			} else {
+				writeYAML(buf, e, indent+2)
+			}
+		}
+	default:
+		buf.WriteString(yamlScalar(v, indent))
+		buf.WriteByte('\n')
+	}
+}
+
+// writeYAMLChild writes the value of a mapping key at indent, after the colon.
+func writeYAMLChild(buf *bytes.Buffer, v any, indent int) {
+	prefix := "\n" + strings.Repeat(" ", indent+2)
+	switch v := v.(type) {
+	case object:
+		if len(v) > 0 {
+			buf.WriteString(prefix)
+			writeYAML(buf, v, indent+2)
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			buf.WriteString(prefix)
+			writeYAML(buf, v, indent+2)
+			return
+		}
+	}
+	buf.WriteByte(' ')
+	writeYAML(buf, v, indent)
+}
+
+// yamlScalar formats a scalar. A multi-line string is a literal block scalar indented by two more
+// spaces than indent.
+func yamlScalar(v any, indent int) string {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if block, ok := yamlBlock(v, indent); ok {
+			return block
+		}
+		return yamlscalar.String(v)
+	}
+	return "null"
+}
+
+// yamlBlock formats s as a literal block scalar if it has multiple lines that can be kept verbatim.
+func yamlBlock(s string, indent int) (string, bool) {
+	body, chomp := s, "|-"
+	if strings.HasSuffix(s, "\n") {
+		body, chomp = s[:len(s)-1], "|"
+	}
+	if !strings.Contains(body, "\n") || strings.HasSuffix(body, "\n") ||
+		strings.HasPrefix(strings.TrimLeft(body, "\n"), " ") || strings.ContainsAny(body, "\r\t") || !yamlscalar.Printable(body) {
+		return "", false
+	}
+	var b strings.Builder
+	b.WriteString(chomp)
+	for _, line := range strings.Split(body, "\n") {
+		b.WriteByte('\n')
+		if line != "" {
+			b.WriteString(strings.Repeat(" ", indent+2))
+			b.WriteString(line)
+		}
+	}
+	return b.String(), true
+}
@@ -0,0 +1,53 @@
+// Package yamlscalar formats strings as YAML scalars, for the YAML written by the qiita command and
+// the front matter written by qiitasync.
+package yamlscalar
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Formats s as a plain scalar, or double-quoted if it would be read as anything else than the same
+// string.
+func String(s string) string {
+	if !NeedsQuote(s) {
+		return s
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Reports whether s would be read as something else than the same string as a plain scalar, e.g. a
+// number, a boolean, null, a comment or a mapping.
+func NeedsQuote(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) || !Printable(s) {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`", rune(s[0])) {
+		return true
+	}
+	return strings.HasSuffix(s, ":") || strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.ContainsAny(s, "\n\r\t")
+}
+
+// Reports whether s has no control characters other than newlines.
+func Printable(s string) bool {
+	for _, r := range s {
+		if (r < ' ' && r != '\n') || r == 0x7f || r == '\uFEFF' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+package yamlscalar
+
+import "testing"
+
+func TestString(t *testing.T) {
+	for s, want := range map[string]string{
+		"c686397e4a0f4f11683d":      "c686397e4a0f4f11683d",
+		"2024-06-01T12:00:00+09:00": "2024-06-01T12:00:00+09:00",
+		"C#":                        "C#",
+		"it's":                      "it's",
+		"12345678901234567890":      `"12345678901234567890"`,
+		"1e10":                      `"1e10"`,
+		"true":                      `"true"`,
+		"Null":                      `"Null"`,
+		"":                          `""`,
+		"a: b":                      `"a: b"`,
+		"C #":                       `"C #"`,
+		"- a":                       `"- a"`,
+		"'quoted'":                  `"'quoted'"`,
+		" padded":                   `" padded"`,
+		"<b>\t":                     `"<b>\t"`,
+		"line\nbreak":               `"line\nbreak"`,
+		"bell\a":                    `"bell\u0007"`,
+	} {
+		if got := String(s); got != want {
+			t.Errorf("String(%q) = %s, want %s", s, got, want)
+		}
+	}
+}
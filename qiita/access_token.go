@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 )
 
@@ -27,16 +25,20 @@ type Auth struct {
 
 	POST /api/v2/access_tokens
 */
-func (c *Client) CreateAccessToken(ctx context.Context, auth Auth) error {
+func (c *Client) CreateAccessToken(ctx context.Context, auth Auth) (*AccessToken, error) {
 	b, _ := json.Marshal(auth)
-	res, err := c.post(ctx, "/api/v2/access_tokens", bytes.NewBuffer(b))
+	res, err := c.post(ctx, "CreateAccessToken", "/api/v2/access_tokens", bytes.NewBuffer(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var created AccessToken
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
 /*
@@ -45,13 +47,16 @@ func (c *Client) CreateAccessToken(ctx context.Context, auth Auth) error {
 	Deactivate an access token.
 */
 func (c *Client) DeleteAccessToken(ctx context.Context, accessToken string) error {
-	p := fmt.Sprintf("/api/v2/access_tokens/%s", accessToken)
-	res, err := c.delete(ctx, p)
+	p, err := apiPath("/api/v2/access_tokens/%s", param("access token", accessToken))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteAccessToken", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
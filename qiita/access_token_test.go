@@ -12,25 +12,28 @@ func TestCreateAccessToken(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusCreated)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_access_token.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateAccessToken(ctx, Auth{})
+		accessToken, err := c.CreateAccessToken(ctx, Auth{})
 		if err != nil {
 			t.Fatal(err)
 		}
+		if accessToken.Token != "ea5d0a593b2655e9568f144fb1826342292f5c6b" {
+			t.Errorf("accessToken.Token = %q", accessToken.Token)
+		}
 	}()
 
 	// 400
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_access_token.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateAccessToken(ctx, Auth{})
+		_, err := c.CreateAccessToken(ctx, Auth{})
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +49,7 @@ func TestDeleteAccessToken(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteAccessToken(ctx, "")
+		err := c.DeleteAccessToken(ctx, "ea5d0a593b2655e9568f144fb1826342292f5c6b")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +63,7 @@ func TestDeleteAccessToken(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteAccessToken(ctx, "")
+		err := c.DeleteAccessToken(ctx, "ea5d0a593b2655e9568f144fb1826342292f5c6b")
 		if err == nil {
 			t.Fail()
 		}
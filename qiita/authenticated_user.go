@@ -2,7 +2,6 @@ package qiita
 
 import (
 	"context"
-	"errors"
 	"net/http"
 )
 
@@ -11,7 +10,6 @@ type AuthenticatedUser struct {
 	*User
 	ImageMonthlyUploadLimit     uint `json:"image_monthly_upload_limit"`
 	ImageMonthlyUploadRemaining uint `json:"image_monthly_upload_remaining"`
-	TeamOnly                    bool `json:"team_only"`
 }
 
 /*
@@ -20,15 +18,15 @@ type AuthenticatedUser struct {
 	GET /api/v2/authenticated_user
 */
 func (c *Client) GetAuthenticatedUser(ctx context.Context) (*AuthenticatedUser, error) {
-	res, err := c.get(ctx, "/api/v2/authenticated_user", nil)
+	res, err := c.get(ctx, "GetAuthenticatedUser", "/api/v2/authenticated_user", nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var authenticatedUser AuthenticatedUser
-	if err := decodeBody(res, &authenticatedUser); err != nil {
+	if err := decodeBody(ctx, res, &authenticatedUser); err != nil {
 		return nil, err
 	}
 	return &authenticatedUser, nil
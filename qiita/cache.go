@@ -0,0 +1,282 @@
+package qiita
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stores responses of GET requests, so that the client revalidates them with If-None-Match and
+// If-Modified-Since instead of downloading them again. Implementations must be safe for concurrent use.
+//
+// Keys are made of the URL and a hash of the access token of the client, so that users of a shared
+// cache do not see each other's responses. Clients without a Token, e.g. authorized by an HTTPClient
+// from golang.org/x/oauth2, do not use the cache.
+//
+// A successful POST, PATCH, PUT or DELETE deletes every response cached for the access token, as a
+// write changes lists and counts elsewhere, e.g. a new item appears in GET /api/v2/items and
+// GET /api/v2/authenticated_user/items, and a like changes likes_count of the item in every list.
+type Cache interface {
+	// Get returns the response stored for key, if any.
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, res *CachedResponse)
+	Delete(key string)
+
+	// DeletePrefix deletes the responses stored for keys starting with prefix.
+	DeletePrefix(prefix string)
+}
+
+// A successful response stored in a Cache.
+type CachedResponse struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+
+	// When the response was received or last revalidated.
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// cacheKey returns the key of the responses of req in a Cache.
+func (c *Client) cacheKey(req *http.Request) string {
+	return c.cachePrefix() + req.URL.String()
+}
+
+// cachePrefix returns the prefix of the keys of all responses cached for the access token.
+func (c *Client) cachePrefix() string {
+	token := sha256.Sum256([]byte(c.Token))
+	return hex.EncodeToString(token[:8]) + " "
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// sendCached sends a GET request through the cache. A fresh response is served without a request,
+// and a stale one is revalidated, being served again if the server responds 304 Not Modified.
+func (c *Client) sendCached(ctx context.Context, req *http.Request) (*http.Response, error) {
+	key := c.cacheKey(req)
+	cached, ok := c.cache.Get(key)
+	if ok && c.cacheTTL > 0 && time.Since(cached.StoredAt) < c.cacheTTL {
+		return cached.response(req), nil
+	}
+	if ok {
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+	res, err := c.sendRetrying(ctx, req)
+	if err != nil {
+		return res, err
+	}
+	switch {
+	case res.StatusCode == http.StatusNotModified && ok:
+		discard(res)
+		cached.StoredAt = time.Now()
+		c.cache.Set(key, cached)
+		return cached.response(req), nil
+	case res.StatusCode == http.StatusOK:
+		if res.Header.Get("ETag") == "" && res.Header.Get("Last-Modified") == "" && c.cacheTTL == 0 {
+			// Without validators, the response could never be served again.
+			c.cache.Delete(key)
+			return res, nil
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		c.cache.Set(key, &CachedResponse{Header: res.Header.Clone(), Body: body, StoredAt: time.Now()})
+	case res.StatusCode == http.StatusNotFound:
+		c.cache.Delete(key)
+	}
+	return res, nil
+}
+
+// An in-memory Cache keeping the most recently used responses.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List // of *memoryEntry, the most recently used first
+}
+
+type memoryEntry struct {
+	key string
+	res *CachedResponse
+}
+
+// Creates a cache of up to capacity responses, evicting the least recently used ones.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{capacity: capacity, entries: map[string]*list.Element{}, lru: list.New()}
+}
+
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(e)
+	res := *e.Value.(*memoryEntry).res
+	return &res, true
+}
+
+func (m *MemoryCache) Set(key string, res *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*memoryEntry).res = res
+		m.lru.MoveToFront(e)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key, res})
+	for m.lru.Len() > max(m.capacity, 0) {
+		e := m.lru.Back()
+		m.lru.Remove(e)
+		delete(m.entries, e.Value.(*memoryEntry).key)
+	}
+}
+
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.lru.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+func (m *MemoryCache) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, e := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.lru.Remove(e)
+			delete(m.entries, key)
+		}
+	}
+}
+
+// Returns the number of responses in the cache.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// A Cache storing each response as a JSON file in a directory, which survives restarts and can be
+// shared by processes. A response which cannot be read or written is treated as missing.
+type DiskCache struct {
+	dir string
+}
+
+// Creates a cache in dir, which is created when the first response is stored.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir}
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// diskEntry is the content of the file of a response.
+type diskEntry struct {
+	Key string `json:"key"`
+	*CachedResponse
+}
+
+func (d *DiskCache) read(path string) (*diskEntry, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	entry := &diskEntry{CachedResponse: &CachedResponse{}}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	entry, ok := d.read(d.path(key))
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+	return entry.CachedResponse, true
+}
+
+func (d *DiskCache) Set(key string, res *CachedResponse) {
+	b, err := json.Marshal(diskEntry{key, res})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.dir, 0o700); err != nil {
+		return
+	}
+	// Write to a temporary file first, so that readers never see a partial response.
+	f, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+func (d *DiskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+// DeletePrefix reads every response in the directory to find their keys.
+func (d *DiskCache) DeletePrefix(prefix string) {
+	entries, _ := os.ReadDir(d.dir)
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(d.dir, e.Name())
+		if entry, ok := d.read(path); ok && strings.HasPrefix(entry.Key, prefix) {
+			os.Remove(path)
+		}
+	}
+}
+
+var (
+	_ Cache = (*MemoryCache)(nil)
+	_ Cache = (*DiskCache)(nil)
+)
@@ -0,0 +1,258 @@
+package qiita
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// Serves the item fixture with the given validators, responding 304 to matching conditional requests,
+// and records the conditional headers of each request.
+func conditionalServer(etag, lastModified string, requests *[]http.Header) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Header.Clone())
+		w.Header().Set("Rate-Limit", "1000")
+		w.Header().Set("Rate-Remaining", "999")
+		w.Header().Set("Rate-Reset", "1717200000")
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		if etag != "" && r.Header.Get("If-None-Match") == etag ||
+			etag == "" && lastModified != "" && r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		b, _ := os.ReadFile("testdata/get_item.json")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}))
+}
+
+func cacheClient(server *httptest.Server, cache Cache, ttl time.Duration) *Client {
+	c, _ := mockClient(server)
+	c.Token = "token"
+	c.cache, c.cacheTTL = cache, ttl
+	return c
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	ctx := context.TODO()
+	for _, tt := range []struct {
+		name, etag, lastModified, header, value string
+	}{
+		{"ETag", `"v1"`, "", "If-None-Match", `"v1"`},
+		{"Last-Modified", "", "Mon, 03 Jun 2024 00:00:00 GMT", "If-Modified-Since", "Mon, 03 Jun 2024 00:00:00 GMT"},
+	} {
+		var requests []http.Header
+		server := conditionalServer(tt.etag, tt.lastModified, &requests)
+		c := cacheClient(server, NewMemoryCache(10), 0)
+
+		first, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		second, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
+		if err != nil {
+			t.Fatalf("%s: GetItem from the cache: %v", tt.name, err)
+		}
+		if second.Title != first.Title || second.Id != first.Id {
+			t.Errorf("%s: cached item = %+v, want %+v", tt.name, second, first)
+		}
+		if len(requests) != 2 || requests[0].Get(tt.header) != "" || requests[1].Get(tt.header) != tt.value {
+			t.Errorf("%s: requests = %v", tt.name, requests)
+		}
+		if c.RateLimit().Remaining != 999 {
+			t.Errorf("%s: rate limit = %+v", tt.name, c.RateLimit())
+		}
+		server.Close()
+	}
+}
+
+func TestCacheKeys(t *testing.T) {
+	ctx := context.TODO()
+	var requests []http.Header
+	server := conditionalServer(`"v1"`, "", &requests)
+	defer server.Close()
+	cache := NewMemoryCache(10)
+	c := cacheClient(server, cache, 0)
+	c.GetItem(ctx, "c686397e4a0f4f11683d")
+
+	// Another token does not share the response.
+	other := cacheClient(server, cache, 0)
+	other.Token = "other"
+	other.GetItem(ctx, "c686397e4a0f4f11683d")
+	if requests[1].Get("If-None-Match") != "" {
+		t.Errorf("a request with another token revalidated the cached response")
+	}
+
+	// Clients without a token, authorized by their HTTPClient, do not use the cache.
+	requests = nil
+	for range 2 {
+		anonymous := cacheClient(server, cache, time.Hour)
+		anonymous.Token = ""
+		if _, err := anonymous.GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(requests) != 2 || requests[1].Get("If-None-Match") != "" {
+		t.Errorf("clients without a token shared a cached response: %v", requests)
+	}
+
+	// A change of the item invalidates its cached response.
+	if err := c.DeleteItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+		t.Fatal(err)
+	}
+	c.GetItem(ctx, "c686397e4a0f4f11683d")
+	if last := requests[len(requests)-1]; last.Get("If-None-Match") != "" {
+		t.Errorf("GET after an update revalidated the cached response")
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	ctx := context.TODO()
+	var requests []http.Header
+	server := conditionalServer("", "", &requests)
+	defer server.Close()
+	cache := NewMemoryCache(10)
+	c := cacheClient(server, cache, time.Hour)
+	other := cacheClient(server, cache, time.Hour)
+	other.Token = "other"
+	for _, c := range []*Client{c, other} {
+		c.GetItem(ctx, "c686397e4a0f4f11683d")
+		c.GetItem(ctx, "4bd431809afb1bb99e4f")
+	}
+
+	// A write makes every response cached for the token stale, not only that of its URL.
+	if err := c.DeleteItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("DeleteItem left %d cached responses, want the 2 of another token", cache.Len())
+	}
+	requests = nil
+	c.GetItem(ctx, "4bd431809afb1bb99e4f")
+	other.GetItem(ctx, "4bd431809afb1bb99e4f")
+	if len(requests) != 1 {
+		t.Errorf("server got %d requests after the write, want 1", len(requests))
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	ctx := context.TODO()
+	var requests []http.Header
+	// Responses without validators are cached for the TTL only.
+	server := conditionalServer("", "", &requests)
+	defer server.Close()
+
+	c := cacheClient(server, NewMemoryCache(10), time.Hour)
+	for range 3 {
+		if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("server got %d requests within the TTL, want 1", len(requests))
+	}
+
+	requests = nil
+	cache := NewMemoryCache(10)
+	c = cacheClient(server, cache, 0)
+	for range 2 {
+		c.GetItem(ctx, "c686397e4a0f4f11683d")
+	}
+	if len(requests) != 2 || cache.Len() != 0 {
+		t.Errorf("without a TTL: %d requests, %d cached responses", len(requests), cache.Len())
+	}
+
+	// A response older than the TTL is requested again.
+	requests = nil
+	c = cacheClient(server, cache, time.Nanosecond)
+	c.GetItem(ctx, "c686397e4a0f4f11683d")
+	time.Sleep(time.Millisecond)
+	c.GetItem(ctx, "c686397e4a0f4f11683d")
+	if len(requests) != 2 {
+		t.Errorf("server got %d requests after the TTL, want 2", len(requests))
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	m := NewMemoryCache(2)
+	m.Set("a", &CachedResponse{Body: []byte("a")})
+	m.Set("b", &CachedResponse{Body: []byte("b")})
+	m.Get("a")
+	m.Set("c", &CachedResponse{Body: []byte("c")})
+	if _, ok := m.Get("b"); ok {
+		t.Error("the least recently used response was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if res, ok := m.Get(key); !ok || string(res.Body) != key {
+			t.Errorf("Get(%s) = %v, %v", key, res, ok)
+		}
+	}
+	m.Delete("a")
+	if _, ok := m.Get("a"); ok || m.Len() != 1 {
+		t.Errorf("Delete(a) left %d responses", m.Len())
+	}
+	m.Set("c2", &CachedResponse{})
+	m.DeletePrefix("c")
+	if m.Len() != 0 {
+		t.Errorf("DeletePrefix(c) left %d responses", m.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	stored := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	NewDiskCache(dir).Set("key", &CachedResponse{Header: http.Header{"Etag": {`"v1"`}}, Body: []byte("{}"), StoredAt: stored})
+
+	d := NewDiskCache(dir)
+	res, ok := d.Get("key")
+	if !ok || string(res.Body) != "{}" || res.Header.Get("ETag") != `"v1"` || !res.StoredAt.Equal(stored) {
+		t.Fatalf("Get(key) = %+v, %v", res, ok)
+	}
+	if _, ok := d.Get("other"); ok {
+		t.Error("Get(other) found a response")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("cache directory has %d files, want 1", len(entries))
+	}
+	d.Delete("key")
+	if _, ok := d.Get("key"); ok {
+		t.Error("Delete(key) left the response")
+	}
+	d.Set("a 1", &CachedResponse{})
+	d.Set("a 2", &CachedResponse{})
+	d.Set("b 1", &CachedResponse{})
+	d.DeletePrefix("a ")
+	if _, ok := d.Get("b 1"); !ok {
+		t.Error("DeletePrefix(a) deleted b 1")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("DeletePrefix(a) left %d files, want 1", len(entries))
+	}
+	d.Delete("b 1")
+
+	// A client revalidates responses cached on disk by another.
+	var requests []http.Header
+	server := conditionalServer(`"v1"`, "", &requests)
+	defer server.Close()
+	ctx := context.TODO()
+	cacheClient(server, NewDiskCache(dir), 0).GetItem(ctx, "c686397e4a0f4f11683d")
+	if _, err := cacheClient(server, NewDiskCache(dir), 0).GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || requests[1].Get("If-None-Match") != `"v1"` {
+		t.Errorf("requests = %v", requests)
+	}
+}
@@ -7,14 +7,26 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"path"
 	"runtime"
+	"slices"
+	"sync"
+	"time"
 )
 
 type Client struct {
 	URL        *url.URL
 	HTTPClient *http.Client
 	Token      string
+
+	waitOnRateLimit bool
+	retryPolicy     RetryPolicy
+	middlewares     []Middleware
+	instrumentation Instrumentation
+	cache           Cache
+	cacheTTL        time.Duration
+
+	mu        sync.Mutex
+	rateLimit RateLimit
 }
 
 var userAgent = fmt.Sprintf("QiitaGoClient/%s (%s)", version, runtime.Version())
@@ -25,86 +37,165 @@ func NewClient(token string, config Config) (*Client, error) {
 		return nil, err
 	}
 	return &Client{
-		URL:        parsedURL,
-		HTTPClient: &http.Client{},
-		Token:      token,
+		URL: parsedURL,
+		HTTPClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		Token: token,
+
+		waitOnRateLimit: config.WaitOnRateLimit,
+		retryPolicy:     config.RetryPolicy,
+		middlewares:     slices.Clone(config.Middlewares),
+		instrumentation: config.Instrumentation,
+		cache:           config.Cache,
+		cacheTTL:        config.CacheTTL,
 	}, nil
 }
 
+// Creates a client for Qiita:Team of the given team ID.
+func NewTeamClient(teamId, token string) (*Client, error) {
+	return NewClient(token, *NewConfig().WithTeam(teamId))
+}
+
+// teamOnly returns ErrTeamOnly if the client targets public Qiita rather than Qiita:Team.
+func (c *Client) teamOnly() error {
+	if c.URL.Hostname() == "qiita.com" {
+		return ErrTeamOnly
+	}
+	return nil
+}
+
 func (c *Client) newRequest(ctx context.Context, method, p string, body io.Reader) (*http.Request, error) {
-	u := *c.URL
-	u.Path = path.Join(c.URL.Path, p)
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, c.url(p), body)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 	req.Header.Set("Authorization", "Bearer "+c.Token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", userAgent)
 	return req, nil
 }
 
-func decodeBody(res *http.Response, out interface{}) error {
+func decodeBody(ctx context.Context, res *http.Response, out interface{}) error {
 	defer res.Body.Close()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	decoder := json.NewDecoder(res.Body)
-	return decoder.Decode(out)
+	if err := decoder.Decode(out); err != nil {
+		// Reading the body fails with a less descriptive error once ctx is done.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
 }
 
+// url resolves endpoint, whose identifiers are already escaped by apiPath, against the base URL.
 func (c *Client) url(endpoint string) string {
-	u := *c.URL
-	u.Path = path.Join(c.URL.Path, endpoint)
-	return u.String()
+	return c.URL.JoinPath(endpoint).String()
 }
 
-func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+// do sends req for operation, the name of the exported method calling the API, e.g. "GetItem".
+func (c *Client) do(ctx context.Context, operation string, req *http.Request) (*http.Response, error) {
+	// Without a token, HTTPClient is left to authorize requests, e.g. one from golang.org/x/oauth2.
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
 	req.Header.Set("User-Agent", userAgent)
-	return c.HTTPClient.Do(req)
+	if c.instrumentation != nil {
+		return c.instrument(ctx, operation, req)
+	}
+	return c.send(ctx, req)
 }
 
-func (c *Client) get(ctx context.Context, endpoint string, rawQuery *string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, c.url(endpoint), nil)
-	if rawQuery != nil {
-		req.URL.RawQuery = *rawQuery
+// send sends req through the cache if it is a GET request and the client has one. Responses to a
+// client without a token are not cached, as nothing tells whose they are.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.cache == nil || c.Token == "" {
+		return c.sendRetrying(ctx, req)
+	}
+	if req.Method == http.MethodGet {
+		return c.sendCached(ctx, req)
+	}
+	res, err := c.sendRetrying(ctx, req)
+	if err == nil && res.StatusCode < http.StatusBadRequest {
+		// The write may change any response cached for the token, e.g. the lists of items.
+		c.cache.DeletePrefix(c.cachePrefix())
+	}
+	return res, err
+}
+
+// sendRetrying sends req, retrying it according to the retry policy.
+func (c *Client) sendRetrying(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if c.waitOnRateLimit {
+			if err := c.waitRateLimit(ctx); err != nil {
+				return nil, err
+			}
+		}
+		res, err := chain(c.HTTPClient, c.middlewares).Do(req)
+		if err == nil {
+			c.updateRateLimit(res.Header)
+		}
+		if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.retryable(req, res, err) {
+			return res, err
+		}
+		if !sleep(ctx, c.retryPolicy.delay(attempt, res)) {
+			return res, err
+		}
+		if res != nil {
+			discard(res)
+		}
+		if err := rewind(req); err != nil {
+			return nil, err
+		}
 	}
+}
+
+func (c *Client) get(ctx context.Context, operation, endpoint string, rawQuery *string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(endpoint), nil)
 	if err != nil {
 		return nil, err
 	}
-	return c.do(ctx, req)
+	if rawQuery != nil {
+		req.URL.RawQuery = *rawQuery
+	}
+	return c.do(ctx, operation, req)
 }
 
-func (client *Client) post(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPost, client.url(endpoint), body)
+func (client *Client) post(ctx context.Context, operation, endpoint string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.url(endpoint), body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	return client.do(ctx, req)
+	return client.do(ctx, operation, req)
 }
 
-func (client *Client) patch(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPatch, client.url(endpoint), body)
+func (client *Client) patch(ctx context.Context, operation, endpoint string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, client.url(endpoint), body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	return client.do(ctx, req)
+	return client.do(ctx, operation, req)
 }
 
-func (client *Client) put(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPut, client.url(endpoint), body)
+func (client *Client) put(ctx context.Context, operation, endpoint string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, client.url(endpoint), body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	return client.do(ctx, req)
+	return client.do(ctx, operation, req)
 }
 
-func (c *Client) delete(ctx context.Context, endpoint string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, c.url(endpoint), nil)
+func (c *Client) delete(ctx context.Context, operation, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.url(endpoint), nil)
 	if err != nil {
 		return nil, err
 	}
-	return c.do(ctx, req)
+	return c.do(ctx, operation, req)
 }
@@ -3,10 +3,12 @@ package qiita
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func mockClient(server *httptest.Server) (*Client, error) {
@@ -41,3 +43,105 @@ func TestNewRequest(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	c, _ := mockClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestContextCanceledWhileDecoding(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	c, _ := mockClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestConfigTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	config := NewConfig().WithEndpoint(server.URL).WithTimeout(50 * time.Millisecond)
+	c, err := NewClient("", *config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.HTTPClient.Transport = server.Client().Transport
+	start := time.Now()
+	if _, err := c.GetItem(context.TODO(), "c686397e4a0f4f11683d"); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v with a 50ms timeout", elapsed)
+	}
+}
+
+func TestNewTeamClient(t *testing.T) {
+	c, err := NewTeamClient("increments", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.url("/api/v2/items"); got != "https://increments.qiita.com/api/v2/items" {
+		t.Errorf("url = %q", got)
+	}
+	if err := c.teamOnly(); err != nil {
+		t.Errorf("teamOnly() = %v", err)
+	}
+}
+
+func TestTeamOnly(t *testing.T) {
+	c, err := NewClient("", *NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.middlewares = []Middleware{func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return nil, errors.New("unexpected request")
+		})
+	}}
+	ctx := context.TODO()
+	if err := c.LikeItem(ctx, "c686397e4a0f4f11683d"); err != ErrTeamOnly {
+		t.Errorf("LikeItem() = %v, want %v", err, ErrTeamOnly)
+	}
+	if _, _, err := c.ListProjects(ctx, 1, 1); err != ErrTeamOnly {
+		t.Errorf("ListProjects() = %v, want %v", err, ErrTeamOnly)
+	}
+	if _, err := c.GetTemplate(ctx, 1); err != ErrTeamOnly {
+		t.Errorf("GetTemplate() = %v, want %v", err, ErrTeamOnly)
+	}
+}
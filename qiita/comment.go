@@ -4,19 +4,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
+	"net/url"
 )
 
 // A comment posted on an item
 type Comment struct {
-	Body         string `json:"body"`
-	CreatedAt    string `json:"created_at,omitempty"`
-	Id           string `json:"id,omitempty"`
-	RenderedBody string `json:"rendered_body,omitempty"`
-	UpdatedAt    string `json:"updated_at,omitempty"`
-	User         User   `json:"user,omitempty"`
+	Body         string    `json:"body"`
+	CreatedAt    Time      `json:"created_at,omitzero"`
+	Id           CommentId `json:"id,omitempty"`
+	RenderedBody string    `json:"rendered_body,omitempty"`
+	UpdatedAt    Time      `json:"updated_at,omitzero"`
+	User         User      `json:"user,omitempty"`
 }
 
 type Comments []Comment
@@ -26,14 +27,17 @@ type Comments []Comment
 
 	DELETE /api/v2/comments/:comment_id
 */
-func (c *Client) DeleteComment(ctx context.Context, commentId string) error {
-	p := fmt.Sprintf("/api/v2/comments/%s", commentId)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteComment(ctx context.Context, commentId CommentId) error {
+	p, err := apiPath("/api/v2/comments/%s", commentId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteComment", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -43,17 +47,20 @@ func (c *Client) DeleteComment(ctx context.Context, commentId string) error {
 
 	GET /api/v2/comments/:comment_id
 */
-func (c *Client) GetComment(ctx context.Context, commentId string) (*Comment, error) {
-	p := fmt.Sprintf("/api/v2/comments/%s", commentId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) GetComment(ctx context.Context, commentId CommentId) (*Comment, error) {
+	p, err := apiPath("/api/v2/comments/%s", commentId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetComment", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var comment Comment
-	if err := decodeBody(res, &comment); err != nil {
+	if err := decodeBody(ctx, res, &comment); err != nil {
 		return nil, err
 	}
 	return &comment, nil
@@ -64,17 +71,24 @@ func (c *Client) GetComment(ctx context.Context, commentId string) (*Comment, er
 
 	PATCH /api/v2/comments/:comment_id
 */
-func (c *Client) UpdateComment(ctx context.Context, comment Comment) error {
+func (c *Client) UpdateComment(ctx context.Context, comment Comment) (*Comment, error) {
 	b, _ := json.Marshal(comment)
-	p := fmt.Sprintf("/api/v2/comments/%s", comment.Id)
-	res, err := c.patch(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/comments/%s", comment.Id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateComment", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var updated Comment
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
 
 /*
@@ -82,17 +96,20 @@ func (c *Client) UpdateComment(ctx context.Context, comment Comment) error {
 
 	List comments on an item in newest order.
 */
-func (c *Client) ListComments(ctx context.Context, itemId string) (*Comments, error) {
-	p := fmt.Sprintf("/api/v2/items/%s/comments", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) ListComments(ctx context.Context, itemId ItemId) (*Comments, error) {
+	p, err := apiPath("/api/v2/items/%s/comments", itemId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "ListComments", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var comments Comments
-	if err := decodeBody(res, &comments); err != nil {
+	if err := decodeBody(ctx, res, &comments); err != nil {
 		return nil, err
 	}
 	return &comments, nil
@@ -103,15 +120,96 @@ func (c *Client) ListComments(ctx context.Context, itemId string) (*Comments, er
 
 	POST /api/v2/items/:item_id/comments
 */
-func (c *Client) PostComment(ctx context.Context, itemId string, comment Comment) error {
+func (c *Client) PostComment(ctx context.Context, itemId ItemId, comment Comment) (*Comment, error) {
 	b, _ := json.Marshal(comment)
-	p := fmt.Sprintf("/api/v2/items/%s/comments", itemId)
-	res, err := c.post(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/items/%s/comments", itemId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.post(ctx, "PostComment", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var posted Comment
+	if err := decodeBody(ctx, res, &posted); err != nil {
+		return nil, err
+	}
+	return &posted, nil
+}
+
+/*
+	List comments on a project in newest order (only available on Qiita:Team).
+
+	GET /api/v2/projects/:project_id/comments
+*/
+func (c *Client) ListProjectComments(ctx context.Context, projectId ProjectId, page, perPage uint) (*Comments, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
+	p, err := apiPath("/api/v2/projects/%s/comments", projectId)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := url.Values{}
+	values.Add("page", fmt.Sprint(page))
+	values.Add("per_page", fmt.Sprint(perPage))
+	rawQuery := values.Encode()
+	res, err := c.get(ctx, "ListProjectComments", p, &rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(res)
+	}
+	var comments Comments
+	if err := decodeBody(ctx, res, &comments); err != nil {
+		return nil, nil, err
+	}
+	return &comments, newPage(res), nil
+}
+
+/*
+	Iterate over comments on a project, fetching every page lazily (only available on Qiita:Team).
+
+	GET /api/v2/projects/:project_id/comments
+*/
+func (c *Client) AllProjectComments(ctx context.Context, projectId ProjectId) iter.Seq2[Comment, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Comment, *Page, error) {
+		comments, p, err := c.ListProjectComments(ctx, projectId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *comments, p, nil
+	})
+}
+
+/*
+	Post a comment on a project (only available on Qiita:Team).
+
+	POST /api/v2/projects/:project_id/comments
+*/
+func (c *Client) PostProjectComment(ctx context.Context, projectId ProjectId, comment Comment) (*Comment, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(comment)
+	p, err := apiPath("/api/v2/projects/%s/comments", projectId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.post(ctx, "PostProjectComment", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(res)
+	}
+	var posted Comment
+	if err := decodeBody(ctx, res, &posted); err != nil {
+		return nil, err
+	}
+	return &posted, nil
 }
@@ -16,7 +16,7 @@ func TestDeleteComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteComment(ctx, "")
+		err := c.DeleteComment(ctx, "3391f50c35f953abfc4f")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestDeleteComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteComment(ctx, "")
+		err := c.DeleteComment(ctx, "3391f50c35f953abfc4f")
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +46,7 @@ func TestGetComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetComment(ctx, "")
+		_, err := c.GetComment(ctx, "3391f50c35f953abfc4f")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +60,7 @@ func TestGetComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetComment(ctx, "")
+		_, err := c.GetComment(ctx, "3391f50c35f953abfc4f")
 		if err == nil {
 			t.Fail()
 		}
@@ -72,11 +72,11 @@ func TestUpdateComment(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_comment.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateComment(ctx, Comment{})
+		_, err := c.UpdateComment(ctx, Comment{Id: "3391f50c35f953abfc4f"})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -86,11 +86,11 @@ func TestUpdateComment(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_comment.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateComment(ctx, Comment{})
+		_, err := c.UpdateComment(ctx, Comment{Id: "3391f50c35f953abfc4f"})
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +106,7 @@ func TestListComments(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListComments(ctx, "")
+		_, err := c.ListComments(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +120,7 @@ func TestListComments(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListComments(ctx, "")
+		_, err := c.ListComments(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -136,7 +136,7 @@ func TestPostComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.PostComment(ctx, "", Comment{})
+		_, err := c.PostComment(ctx, "c686397e4a0f4f11683d", Comment{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -150,7 +150,79 @@ func TestPostComment(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.PostComment(ctx, "", Comment{})
+		_, err := c.PostComment(ctx, "c686397e4a0f4f11683d", Comment{})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestListProjectComments(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/projects/1/comments" || r.URL.Query().Get("page") != "2" {
+				t.Errorf("request = %s", r.URL)
+			}
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_project_comments.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		comments, _, err := c.ListProjectComments(ctx, 1, 2, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(*comments) != 1 || (*comments)[0].Id != "3391f50c35f953abfc4f" {
+			t.Errorf("comments = %+v", comments)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/list_project_comments.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListProjectComments(ctx, 1, 1, 1)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestPostProjectComment(t *testing.T) {
+	// 201
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/api/v2/projects/1/comments" {
+				t.Errorf("request = %s %s", r.Method, r.URL)
+			}
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/post_project_comment.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		comment, err := c.PostProjectComment(ctx, 1, Comment{Body: "# Example"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if comment.Id != "3391f50c35f953abfc4f" {
+			t.Errorf("comment = %+v", comment)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/post_project_comment.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.PostProjectComment(ctx, 1, Comment{})
 		if err == nil {
 			t.Fail()
 		}
@@ -1,12 +1,42 @@
 package qiita
 
+import (
+	"fmt"
+	"time"
+)
+
 type Config struct {
 	Endpoint string
+
+	// Default time limit for each request including reading the response body. Zero means no timeout.
+	Timeout time.Duration
+
+	// Block requests until Rate-Reset once the rate limit is exhausted, instead of sending them.
+	WaitOnRateLimit bool
+
+	// Retries of failed requests, disabled by default.
+	RetryPolicy RetryPolicy
+
+	// Middlewares wrapping each request, the first one being the outermost.
+	Middlewares []Middleware
+
+	// Observes every API call, e.g. for tracing and metrics. Nil disables instrumentation.
+	Instrumentation Instrumentation
+
+	// Stores responses of GET requests to revalidate them with ETag and Last-Modified. Nil disables caching,
+	// as does an empty Token.
+	Cache Cache
+
+	// Serves a cached response younger than CacheTTL without a request, and caches responses without
+	// ETag and Last-Modified for as long. Zero revalidates every cached response.
+	CacheTTL time.Duration
 }
 
 // APIEndpoint constants
 const (
-	APIEndpointBase = "http://qiita.com"
+	APIEndpointBase = "https://qiita.com"
+	// Endpoint of a team on Qiita:Team, formatted with the team ID.
+	APIEndpointTeam = "https://%s.qiita.com"
 )
 
 func NewConfig() *Config {
@@ -19,3 +49,44 @@ func (c *Config) WithEndpoint(endpoint string) *Config {
 	c.Endpoint = endpoint
 	return c
 }
+
+// Targets Qiita:Team of the given team ID, e.g. "increments" for https://increments.qiita.com.
+func (c *Config) WithTeam(teamId string) *Config {
+	c.Endpoint = fmt.Sprintf(APIEndpointTeam, teamId)
+	return c
+}
+
+func (c *Config) WithWaitOnRateLimit(wait bool) *Config {
+	c.WaitOnRateLimit = wait
+	return c
+}
+
+func (c *Config) WithTimeout(timeout time.Duration) *Config {
+	c.Timeout = timeout
+	return c
+}
+
+func (c *Config) WithRetryPolicy(policy RetryPolicy) *Config {
+	c.RetryPolicy = policy
+	return c
+}
+
+func (c *Config) WithMiddleware(middlewares ...Middleware) *Config {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+	return c
+}
+
+func (c *Config) WithInstrumentation(instrumentation Instrumentation) *Config {
+	c.Instrumentation = instrumentation
+	return c
+}
+
+func (c *Config) WithCache(cache Cache) *Config {
+	c.Cache = cache
+	return c
+}
+
+func (c *Config) WithCacheTTL(ttl time.Duration) *Config {
+	c.CacheTTL = ttl
+	return c
+}
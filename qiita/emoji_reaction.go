@@ -4,17 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 )
 
 // An emoji reaction.
 type Reaction struct {
-	CreatedAt string `json:"created_at,omitemoty"`
-	ImageUrl  string `json:"image_url,omitemoty"`
+	CreatedAt Time   `json:"created_at,omitzero"`
+	ImageUrl  string `json:"image_url,omitempty"`
 	Name      string `json:"name"`
-	User      User   `json:"user,omitemoty"`
+	User      User   `json:"user,omitempty"`
 }
 
 type Reactions []Reaction
@@ -24,17 +22,24 @@ type Reactions []Reaction
 
 	POST /api/v2/comments/:comment_id/reactions
 */
-func (c *Client) AddCommentReaction(ctx context.Context, commentId string, reaction Reaction) error {
+func (c *Client) AddCommentReaction(ctx context.Context, commentId CommentId, reaction Reaction) (*Reaction, error) {
 	b, _ := json.Marshal(reaction)
-	p := fmt.Sprintf("/api/v2/comments/%s/reactions", commentId)
-	res, err := c.post(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/comments/%s/reactions", commentId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.post(ctx, "AddCommentReaction", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var added Reaction
+	if err := decodeBody(ctx, res, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
 }
 
 /*
@@ -42,17 +47,24 @@ func (c *Client) AddCommentReaction(ctx context.Context, commentId string, react
 
 	POST /api/v2/items/:item_id/reactions
 */
-func (c *Client) AddItemReaction(ctx context.Context, itemId string, reaction Reaction) error {
+func (c *Client) AddItemReaction(ctx context.Context, itemId ItemId, reaction Reaction) (*Reaction, error) {
 	b, _ := json.Marshal(reaction)
-	p := fmt.Sprintf("/api/v2/items/%s/reactions", itemId)
-	res, err := c.post(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/items/%s/reactions", itemId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.post(ctx, "AddItemReaction", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var added Reaction
+	if err := decodeBody(ctx, res, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
 }
 
 /*
@@ -60,17 +72,27 @@ func (c *Client) AddItemReaction(ctx context.Context, itemId string, reaction Re
 
 	POST /api/v2/projects/:project_id/reactions
 */
-func (c *Client) AddProjectReaction(ctx context.Context, projectId uint, reaction Reaction) error {
+func (c *Client) AddProjectReaction(ctx context.Context, projectId ProjectId, reaction Reaction) (*Reaction, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(reaction)
-	p := fmt.Sprintf("/api/v2/projects/%d/reactions", projectId)
-	res, err := c.post(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/projects/%s/reactions", projectId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.post(ctx, "AddProjectReaction", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var added Reaction
+	if err := decodeBody(ctx, res, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
 }
 
 /*
@@ -78,14 +100,17 @@ func (c *Client) AddProjectReaction(ctx context.Context, projectId uint, reactio
 
 	DELETE /api/v2/comments/:comment_id/reactions/:reaction_name
 */
-func (c *Client) DeleteCommentReaction(ctx context.Context, commentId, reactionName string) error {
-	p := fmt.Sprintf("/api/v2/comments/%s/reactions/%s", commentId, reactionName)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteCommentReaction(ctx context.Context, commentId CommentId, reactionName string) error {
+	p, err := apiPath("/api/v2/comments/%s/reactions/%s", commentId, param("reaction name", reactionName))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteCommentReaction", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -95,14 +120,17 @@ func (c *Client) DeleteCommentReaction(ctx context.Context, commentId, reactionN
 
 	DELETE /api/v2/items/:item_id/reactions/:reaction_name
 */
-func (c *Client) DeleteItemReaction(ctx context.Context, itemId, reactionName string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/reactions/%s", itemId, reactionName)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteItemReaction(ctx context.Context, itemId ItemId, reactionName string) error {
+	p, err := apiPath("/api/v2/items/%s/reactions/%s", itemId, param("reaction name", reactionName))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteItemReaction", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -112,14 +140,20 @@ func (c *Client) DeleteItemReaction(ctx context.Context, itemId, reactionName st
 
 	DELETE /api/v2/projects/:project_id/reactions/:reaction_name
 */
-func (c *Client) DeleteProjectReaction(ctx context.Context, projectId uint, reactionName string) error {
-	p := fmt.Sprintf("/api/v2/projects/%d/reactions/%s", projectId, reactionName)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteProjectReaction(ctx context.Context, projectId ProjectId, reactionName string) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/projects/%s/reactions/%s", projectId, param("reaction name", reactionName))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteProjectReaction", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -129,17 +163,20 @@ func (c *Client) DeleteProjectReaction(ctx context.Context, projectId uint, reac
 
 	GET /api/v2/comments/:comment_id/reactions
 */
-func (c *Client) ListCommentReactions(ctx context.Context, commentId string) (*Reactions, error) {
-	p := fmt.Sprintf("/api/v2/comments/%s/reactions", commentId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) ListCommentReactions(ctx context.Context, commentId CommentId) (*Reactions, error) {
+	p, err := apiPath("/api/v2/comments/%s/reactions", commentId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "ListCommentReactions", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var reactions Reactions
-	if err := decodeBody(res, &reactions); err != nil {
+	if err := decodeBody(ctx, res, &reactions); err != nil {
 		return nil, err
 	}
 	return &reactions, nil
@@ -150,17 +187,20 @@ func (c *Client) ListCommentReactions(ctx context.Context, commentId string) (*R
 
 	GET /api/v2/items/:item_id/reactions
 */
-func (c *Client) ListItemReactions(ctx context.Context, itemId string) (*Reactions, error) {
-	p := fmt.Sprintf("/api/v2/items/%s/reactions", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) ListItemReactions(ctx context.Context, itemId ItemId) (*Reactions, error) {
+	p, err := apiPath("/api/v2/items/%s/reactions", itemId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "ListItemReactions", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var reactions Reactions
-	if err := decodeBody(res, &reactions); err != nil {
+	if err := decodeBody(ctx, res, &reactions); err != nil {
 		return nil, err
 	}
 	return &reactions, nil
@@ -171,17 +211,23 @@ func (c *Client) ListItemReactions(ctx context.Context, itemId string) (*Reactio
 
 	GET /api/v2/projects/:project_id/reactions
 */
-func (c *Client) ListProjectReactions(ctx context.Context, projectId string) (*Reactions, error) {
-	p := fmt.Sprintf("/api/v2/projects/%s/reactions", projectId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) ListProjectReactions(ctx context.Context, projectId ProjectId) (*Reactions, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	p, err := apiPath("/api/v2/projects/%s/reactions", projectId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "ListProjectReactions", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var reactions Reactions
-	if err := decodeBody(res, &reactions); err != nil {
+	if err := decodeBody(ctx, res, &reactions); err != nil {
 		return nil, err
 	}
 	return &reactions, nil
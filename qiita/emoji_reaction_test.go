@@ -16,7 +16,7 @@ func TestAddCommentReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddCommentReaction(ctx, "", Reaction{})
+		_, err := c.AddCommentReaction(ctx, "3391f50c35f953abfc4f", Reaction{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestAddCommentReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddCommentReaction(ctx, "", Reaction{})
+		_, err := c.AddCommentReaction(ctx, "3391f50c35f953abfc4f", Reaction{})
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +46,7 @@ func TestAddItemReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddItemReaction(ctx, "", Reaction{})
+		_, err := c.AddItemReaction(ctx, "c686397e4a0f4f11683d", Reaction{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +60,7 @@ func TestAddItemReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddItemReaction(ctx, "", Reaction{})
+		_, err := c.AddItemReaction(ctx, "c686397e4a0f4f11683d", Reaction{})
 		if err == nil {
 			t.Fail()
 		}
@@ -76,7 +76,7 @@ func TestAddProjectReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddProjectReaction(ctx, 1, Reaction{})
+		_, err := c.AddProjectReaction(ctx, 1, Reaction{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -90,7 +90,7 @@ func TestAddProjectReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddProjectReaction(ctx, 1, Reaction{})
+		_, err := c.AddProjectReaction(ctx, 1, Reaction{})
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +106,7 @@ func TestDeleteCommentReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteCommentReaction(ctx, "", "")
+		err := c.DeleteCommentReaction(ctx, "3391f50c35f953abfc4f", "+1")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +120,7 @@ func TestDeleteCommentReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteCommentReaction(ctx, "", "")
+		err := c.DeleteCommentReaction(ctx, "3391f50c35f953abfc4f", "+1")
 		if err == nil {
 			t.Fail()
 		}
@@ -136,7 +136,7 @@ func TestDeleteItemReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItemReaction(ctx, "", "")
+		err := c.DeleteItemReaction(ctx, "c686397e4a0f4f11683d", "+1")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -150,7 +150,7 @@ func TestDeleteItemReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItemReaction(ctx, "", "")
+		err := c.DeleteItemReaction(ctx, "c686397e4a0f4f11683d", "+1")
 		if err == nil {
 			t.Fail()
 		}
@@ -166,7 +166,7 @@ func TestDeleteProjectReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteProjectReaction(ctx, 1, "")
+		err := c.DeleteProjectReaction(ctx, 1, "+1")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -180,7 +180,7 @@ func TestDeleteProjectReaction(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteProjectReaction(ctx, 1, "")
+		err := c.DeleteProjectReaction(ctx, 1, "+1")
 		if err == nil {
 			t.Fail()
 		}
@@ -196,7 +196,7 @@ func TestListCommentReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListCommentReactions(ctx, "")
+		_, err := c.ListCommentReactions(ctx, "3391f50c35f953abfc4f")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -210,7 +210,7 @@ func TestListCommentReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListCommentReactions(ctx, "")
+		_, err := c.ListCommentReactions(ctx, "3391f50c35f953abfc4f")
 		if err == nil {
 			t.Fail()
 		}
@@ -226,7 +226,7 @@ func TestListItemReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItemReactions(ctx, "")
+		_, err := c.ListItemReactions(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -240,7 +240,7 @@ func TestListItemReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItemReactions(ctx, "")
+		_, err := c.ListItemReactions(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -256,7 +256,7 @@ func TestListProjectReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListProjectReactions(ctx, "")
+		_, err := c.ListProjectReactions(ctx, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -270,7 +270,7 @@ func TestListProjectReactions(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListProjectReactions(ctx, "")
+		_, err := c.ListProjectReactions(ctx, 1)
 		if err == nil {
 			t.Fail()
 		}
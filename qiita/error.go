@@ -0,0 +1,101 @@
+package qiita
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Returned without sending a request when a method only available on Qiita:Team is called against public Qiita.
+var ErrTeamOnly = errors.New("qiita: only available on Qiita:Team")
+
+// Returned without sending a request when an id or another value embedded in the endpoint path
+// is empty or a dot segment.
+type ValidationError struct {
+	Field string
+	Value string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("qiita: %s is empty", e.Field)
+	}
+	return fmt.Sprintf("qiita: invalid %s %q", e.Field, e.Value)
+}
+
+// Sentinel errors which an *APIError matches with errors.Is.
+var (
+	ErrBadRequest   = errors.New("qiita: bad request")
+	ErrUnauthorized = errors.New("qiita: unauthorized")
+	ErrForbidden    = errors.New("qiita: forbidden")
+	ErrNotFound     = errors.New("qiita: not found")
+	ErrRateLimited  = errors.New("qiita: rate limited")
+)
+
+// An error response returned from Qiita API v2
+type APIError struct {
+	StatusCode int         `json:"-"`
+	Status     string      `json:"-"`
+	Type       string      `json:"type"`
+	Message    string      `json:"message"`
+	RequestId  string      `json:"-"`
+	Method     string      `json:"-"`
+	Endpoint   string      `json:"-"`
+	Header     http.Header `json:"-"`
+	Body       []byte      `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("qiita: %s %s: %s", e.Method, e.Endpoint, e.Status)
+	if e.Type != "" {
+		msg += fmt.Sprintf(" (%s)", e.Type)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden && !e.rateLimited()
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.rateLimited()
+	}
+	return false
+}
+
+// Qiita reports an exhausted rate limit as 403 with the rate_limit_exceeded type.
+func (e *APIError) rateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.Type == "rate_limit_exceeded"
+}
+
+func newAPIError(res *http.Response) error {
+	defer res.Body.Close()
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		RequestId:  res.Header.Get("X-Request-Id"),
+		Header:     res.Header,
+	}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.Endpoint = res.Request.URL.Path
+	}
+	if b, err := io.ReadAll(res.Body); err == nil {
+		e.Body = b
+		// The body is not guaranteed to be a JSON error object.
+		json.Unmarshal(b, e)
+	}
+	return e
+}
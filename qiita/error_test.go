@@ -0,0 +1,69 @@
+package qiita
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		sentinel error
+		typ      string
+	}{
+		{http.StatusBadRequest, `{"message":"Bad request","type":"bad_request"}`, ErrBadRequest, "bad_request"},
+		{http.StatusUnauthorized, `{"message":"Unauthorized","type":"unauthorized"}`, ErrUnauthorized, "unauthorized"},
+		{http.StatusForbidden, `{"message":"Forbidden","type":"forbidden"}`, ErrForbidden, "forbidden"},
+		{http.StatusNotFound, `{"message":"Not found","type":"not_found"}`, ErrNotFound, "not_found"},
+		{http.StatusForbidden, `{"message":"Rate limit exceeded","type":"rate_limit_exceeded"}`, ErrRateLimited, "rate_limit_exceeded"},
+		{http.StatusTooManyRequests, `not json`, ErrRateLimited, ""},
+	}
+	for _, tt := range tests {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "request-id")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
+		server.Close()
+
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("status %d: errors.Is(%v, %v) = false", tt.status, err, tt.sentinel)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: error %T is not *APIError", tt.status, err)
+		}
+		if apiErr.StatusCode != tt.status {
+			t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+		}
+		if apiErr.Type != tt.typ {
+			t.Errorf("Type = %q, want %q", apiErr.Type, tt.typ)
+		}
+		if apiErr.RequestId != "request-id" {
+			t.Errorf("RequestId = %q, want %q", apiErr.RequestId, "request-id")
+		}
+		if apiErr.Method != http.MethodGet || apiErr.Endpoint != "/api/v2/items/c686397e4a0f4f11683d" {
+			t.Errorf("request = %s %s", apiErr.Method, apiErr.Endpoint)
+		}
+		if string(apiErr.Body) != tt.body {
+			t.Errorf("Body = %q, want %q", apiErr.Body, tt.body)
+		}
+	}
+}
+
+func TestAPIErrorIsOnlyItsOwnKind(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusForbidden, Type: "rate_limit_exceeded"}
+	if errors.Is(err, ErrForbidden) {
+		t.Error("rate limit error should not match ErrForbidden")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("rate limit error should not match ErrNotFound")
+	}
+}
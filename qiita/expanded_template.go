@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 )
 
@@ -21,16 +20,19 @@ type ExpandedTemplate struct {
 	POST /api/v2/expanded_templates
 */
 func (c *Client) CreateExpandedTemplate(ctx context.Context, template Template) (*ExpandedTemplate, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(template)
-	res, err := c.post(ctx, "/api/v2/expanded_templates", bytes.NewBuffer(b))
+	res, err := c.post(ctx, "CreateExpandedTemplate", "/api/v2/expanded_templates", bytes.NewBuffer(b))
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var expanded_template ExpandedTemplate
-	if err := decodeBody(res, &expanded_template); err != nil {
+	if err := decodeBody(ctx, res, &expanded_template); err != nil {
 		return nil, err
 	}
 	return &expanded_template, nil
@@ -1,11 +1,311 @@
 package qiita
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
 // Represents a group on Qiita:Team
 type Group struct {
-	CreatedAt string `json:"created_at"`
-	Id        uint   `json:"id"`
-	Name      string `json:"name"`
-	Private   bool   `json:"private"`
-	UpdatedAt string `json:"updated_at"`
-	UrlName   string `json:"url_name"`
+	CreatedAt   Time   `json:"created_at,omitzero"`
+	Description string `json:"description,omitempty"`
+	Id          uint   `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Private     bool   `json:"private"`
+	UpdatedAt   Time   `json:"updated_at,omitzero"`
+	UrlName     string `json:"url_name"`
+}
+
+type Groups []Group
+
+// Role of a member in a group
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"
+	GroupRoleMember GroupRole = "member"
+)
+
+// Represents a member of a group on Qiita:Team
+type GroupMember struct {
+	Email string    `json:"email,omitempty"`
+	Id    UserId    `json:"id"`
+	Name  string    `json:"name,omitempty"`
+	Role  GroupRole `json:"role"`
+}
+
+type GroupMembers []GroupMember
+
+/*
+	List groups in a team in newest order (only available on Qiita:Team).
+
+	GET /api/v2/groups
+*/
+func (c *Client) ListGroups(ctx context.Context, page, perPage uint) (*Groups, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
+	values := url.Values{}
+	values.Add("page", fmt.Sprint(page))
+	values.Add("per_page", fmt.Sprint(perPage))
+	rawQuery := values.Encode()
+	res, err := c.get(ctx, "ListGroups", "/api/v2/groups", &rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(res)
+	}
+	var groups Groups
+	if err := decodeBody(ctx, res, &groups); err != nil {
+		return nil, nil, err
+	}
+	return &groups, newPage(res), nil
+}
+
+/*
+	Iterate over groups in a team, fetching every page lazily (only available on Qiita:Team).
+
+	GET /api/v2/groups
+*/
+func (c *Client) AllGroups(ctx context.Context) iter.Seq2[Group, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Group, *Page, error) {
+		groups, p, err := c.ListGroups(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *groups, p, nil
+	})
+}
+
+/*
+	Get a group (only available on Qiita:Team).
+
+	GET /api/v2/groups/:url_name
+*/
+func (c *Client) GetGroup(ctx context.Context, urlName string) (*Group, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	p, err := apiPath("/api/v2/groups/%s", param("url name", urlName))
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetGroup", p, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+	var group Group
+	if err := decodeBody(ctx, res, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+/*
+	Create a new group (only available on Qiita:Team).
+
+	POST /api/v2/groups
+*/
+func (c *Client) CreateGroup(ctx context.Context, group Group) (*Group, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(group)
+	res, err := c.post(ctx, "CreateGroup", "/api/v2/groups", bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(res)
+	}
+	var created Group
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+/*
+	Update a group (only available on Qiita:Team).
+
+	PATCH /api/v2/groups/:url_name
+*/
+func (c *Client) UpdateGroup(ctx context.Context, group Group) (*Group, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(group)
+	p, err := apiPath("/api/v2/groups/%s", param("url name", group.UrlName))
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateGroup", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+	var updated Group
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+/*
+	Delete a group (only available on Qiita:Team).
+
+	DELETE /api/v2/groups/:url_name
+*/
+func (c *Client) DeleteGroup(ctx context.Context, urlName string) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/groups/%s", param("url name", urlName))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteGroup", p)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+	return nil
+}
+
+/*
+	List members of a group (only available on Qiita:Team).
+
+	GET /api/v2/groups/:url_name/members
+*/
+func (c *Client) ListGroupMembers(ctx context.Context, urlName string, page, perPage uint) (*GroupMembers, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
+	p, err := apiPath("/api/v2/groups/%s/members", param("url name", urlName))
+	if err != nil {
+		return nil, nil, err
+	}
+	values := url.Values{}
+	values.Add("page", fmt.Sprint(page))
+	values.Add("per_page", fmt.Sprint(perPage))
+	rawQuery := values.Encode()
+	res, err := c.get(ctx, "ListGroupMembers", p, &rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(res)
+	}
+	var members GroupMembers
+	if err := decodeBody(ctx, res, &members); err != nil {
+		return nil, nil, err
+	}
+	return &members, newPage(res), nil
+}
+
+/*
+	Iterate over members of a group, fetching every page lazily (only available on Qiita:Team).
+
+	GET /api/v2/groups/:url_name/members
+*/
+func (c *Client) AllGroupMembers(ctx context.Context, urlName string) iter.Seq2[GroupMember, error] {
+	return paginate(ctx, func(page, perPage uint) ([]GroupMember, *Page, error) {
+		members, p, err := c.ListGroupMembers(ctx, urlName, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *members, p, nil
+	})
+}
+
+/*
+	Add a user to a group with a role (only available on Qiita:Team).
+
+	POST /api/v2/groups/:url_name/members
+*/
+func (c *Client) AddGroupMember(ctx context.Context, urlName string, member GroupMember) (*GroupMember, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(member)
+	p, err := apiPath("/api/v2/groups/%s/members", param("url name", urlName))
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.post(ctx, "AddGroupMember", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(res)
+	}
+	var added GroupMember
+	if err := decodeBody(ctx, res, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+/*
+	Change the role of a member in a group (only available on Qiita:Team).
+
+	PATCH /api/v2/groups/:url_name/members/:user_id
+*/
+func (c *Client) UpdateGroupMember(ctx context.Context, urlName string, member GroupMember) (*GroupMember, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(member)
+	p, err := apiPath("/api/v2/groups/%s/members/%s", param("url name", urlName), member.Id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateGroupMember", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+	var updated GroupMember
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+/*
+	Remove a user from a group (only available on Qiita:Team).
+
+	DELETE /api/v2/groups/:url_name/members/:user_id
+*/
+func (c *Client) RemoveGroupMember(ctx context.Context, urlName string, userId UserId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/groups/%s/members/%s", param("url name", urlName), userId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "RemoveGroupMember", p)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+	return nil
 }
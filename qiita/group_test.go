@@ -0,0 +1,320 @@
+package qiita
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListGroups(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_groups.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListGroups(ctx, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/list_groups.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListGroups(ctx, 1, 1)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestGetGroup(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/get_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.GetGroup(ctx, "dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/get_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.GetGroup(ctx, "dev")
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestCreateGroup(t *testing.T) {
+	// 201
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/create_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.CreateGroup(ctx, Group{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/create_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.CreateGroup(ctx, Group{})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestUpdateGroup(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/update_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.UpdateGroup(ctx, Group{UrlName: "dev"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/update_group.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.UpdateGroup(ctx, Group{UrlName: "dev"})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestDeleteGroup(t *testing.T) {
+	// 204
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.DeleteGroup(ctx, "dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.DeleteGroup(ctx, "dev")
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestListGroupMembers(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_group_members.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListGroupMembers(ctx, "dev", 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/list_group_members.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListGroupMembers(ctx, "dev", 1, 1)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestAddGroupMember(t *testing.T) {
+	// 201
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/add_group_member.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.AddGroupMember(ctx, "dev", GroupMember{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/add_group_member.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.AddGroupMember(ctx, "dev", GroupMember{})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestUpdateGroupMember(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/update_group_member.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.UpdateGroupMember(ctx, "dev", GroupMember{Id: "yaotti"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/update_group_member.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.UpdateGroupMember(ctx, "dev", GroupMember{Id: "yaotti"})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestRemoveGroupMember(t *testing.T) {
+	// 204
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RemoveGroupMember(ctx, "dev", "yaotti")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RemoveGroupMember(ctx, "dev", "yaotti")
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestGroupMemberRole(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var member GroupMember
+		json.NewDecoder(r.Body).Decode(&member)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/groups/dev/members":
+			if member != (GroupMember{Id: "yaotti", Role: GroupRoleMember}) {
+				t.Errorf("member = %+v", member)
+			}
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/add_group_member.json")
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/groups/dev/members/yaotti":
+			if member.Role != GroupRoleOwner {
+				t.Errorf("member = %+v", member)
+			}
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/update_group_member.json")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+
+	added, err := c.AddGroupMember(ctx, "dev", GroupMember{Id: "yaotti", Role: GroupRoleMember})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Role != GroupRoleMember || added.Name != "Hiroshige Umino" {
+		t.Errorf("added = %+v", added)
+	}
+	updated, err := c.UpdateGroupMember(ctx, "dev", GroupMember{Id: "yaotti", Role: GroupRoleOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Role != GroupRoleOwner {
+		t.Errorf("updated = %+v", updated)
+	}
+}
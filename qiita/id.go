@@ -0,0 +1,80 @@
+package qiita
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Identifier of an item, such as "c686397e4a0f4f11683d"
+type ItemId string
+
+// Identifier of a comment
+type CommentId string
+
+// Identifier of a user, which is their screen name such as "qiita"
+type UserId string
+
+// Identifier of a tag, which is its name such as "Go"
+type TagId string
+
+// Identifier of a project (only available on Qiita:Team)
+type ProjectId uint
+
+// Identifier of a template (only available on Qiita:Team)
+type TemplateId uint
+
+// A value which can be embedded in an endpoint path as a single segment.
+type pathSegment interface {
+	segment() (string, error)
+}
+
+func (id ItemId) segment() (string, error)     { return stringSegment("item id", string(id)) }
+func (id CommentId) segment() (string, error)  { return stringSegment("comment id", string(id)) }
+func (id UserId) segment() (string, error)     { return stringSegment("user id", string(id)) }
+func (id TagId) segment() (string, error)      { return stringSegment("tag id", string(id)) }
+func (id ProjectId) segment() (string, error)  { return uintSegment("project id", id) }
+func (id TemplateId) segment() (string, error) { return uintSegment("template id", id) }
+
+func (id ProjectId) String() string  { return strconv.FormatUint(uint64(id), 10) }
+func (id TemplateId) String() string { return strconv.FormatUint(uint64(id), 10) }
+
+// A path segment other than an id, such as a group url name or a reaction name.
+type pathParam struct {
+	name  string
+	value string
+}
+
+func param(name, value string) pathParam { return pathParam{name, value} }
+
+func (p pathParam) segment() (string, error) { return stringSegment(p.name, p.value) }
+
+// stringSegment escapes value so that it stays a single segment even if it contains "/", "?", "#" or "%".
+// Dot segments are rejected rather than escaped, because servers resolve them after decoding.
+func stringSegment(name, value string) (string, error) {
+	if value == "" || value == "." || value == ".." {
+		return "", &ValidationError{Field: name, Value: value}
+	}
+	return url.PathEscape(value), nil
+}
+
+func uintSegment(name string, id fmt.Stringer) (string, error) {
+	if s := id.String(); s != "0" {
+		return s, nil
+	}
+	return "", &ValidationError{Field: name, Value: "0"}
+}
+
+// apiPath formats an endpoint path with validated and escaped segments, so that a request with
+// an invalid id fails before it is sent.
+func apiPath(format string, segments ...pathSegment) (string, error) {
+	args := make([]any, len(segments))
+	for i, segment := range segments {
+		s, err := segment.segment()
+		if err != nil {
+			return "", err
+		}
+		args[i] = s
+	}
+	return fmt.Sprintf(format, args...), nil
+}
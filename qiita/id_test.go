@@ -0,0 +1,105 @@
+package qiita
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvalidIds(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+	calls := map[string]func() error{
+		"GetItem": func() error {
+			_, err := c.GetItem(ctx, "")
+			return err
+		},
+		"UpdateItem": func() error {
+			_, err := c.UpdateItem(ctx, Item{Title: "title"})
+			return err
+		},
+		"GetComment": func() error {
+			_, err := c.GetComment(ctx, "")
+			return err
+		},
+		"GetUser": func() error {
+			_, err := c.GetUser(ctx, "")
+			return err
+		},
+		"FollowTag": func() error {
+			return c.FollowTag(ctx, "")
+		},
+		"GetProject": func() error {
+			_, err := c.GetProject(ctx, 0)
+			return err
+		},
+		"ListProjectReactions": func() error {
+			_, err := c.ListProjectReactions(ctx, 0)
+			return err
+		},
+		"DeleteTemplate": func() error {
+			return c.DeleteTemplate(ctx, 0)
+		},
+		"DeleteItemReaction": func() error {
+			return c.DeleteItemReaction(ctx, "c686397e4a0f4f11683d", "")
+		},
+		"GetGroup": func() error {
+			_, err := c.GetGroup(ctx, "..")
+			return err
+		},
+	}
+	for name, call := range calls {
+		var verr *ValidationError
+		if err := call(); !errors.As(err, &verr) {
+			t.Errorf("%s: err = %v, want a *ValidationError", name, err)
+		}
+	}
+}
+
+func TestIdEscaped(t *testing.T) {
+	tests := []struct {
+		call func(c *Client) error
+		path string
+	}{
+		{
+			func(c *Client) error {
+				_, err := c.GetItem(context.TODO(), "a/b")
+				return err
+			},
+			"/api/v2/items/a%2Fb",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetUser(context.TODO(), "a b")
+				return err
+			},
+			"/api/v2/users/a%20b",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetProject(context.TODO(), 42)
+				return err
+			},
+			"/api/v2/projects/42",
+		},
+	}
+	for _, tt := range tests {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != tt.path {
+				t.Errorf("path = %q, want %q", r.URL.EscapedPath(), tt.path)
+			}
+			w.Write([]byte("{}"))
+		}))
+		c, _ := mockClient(server)
+		if err := tt.call(c); err != nil {
+			t.Error(err)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,83 @@
+package qiita
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A single API call observed by an Instrumentation. A call spans all of its retries.
+type Call struct {
+	// Name of the logical operation, e.g. "qiita.GetItem".
+	Operation string
+	Method    string
+	Path      string
+	// Page and PerPage are 0 unless the call is a paginated list.
+	Page    uint
+	PerPage uint
+
+	// The following are set when the call ends.
+	// StatusCode is 0 when no response was received, and ErrorType is the type of a Qiita error response.
+	StatusCode int
+	ErrorType  string
+	RateLimit  RateLimit
+	Duration   time.Duration
+	Err        error
+}
+
+// Observes every API call, e.g. to create a tracing span and record request count and latency metrics.
+type Instrumentation interface {
+	// Start is called before a call is sent. The returned context is used for the request.
+	Start(ctx context.Context, call *Call) context.Context
+	// End is called with the context returned by Start once the response headers arrive or the call fails.
+	End(ctx context.Context, call *Call)
+}
+
+func (c *Client) instrument(ctx context.Context, operation string, req *http.Request) (*http.Response, error) {
+	call := &Call{
+		Operation: "qiita." + operation,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+	}
+	query := req.URL.Query()
+	if page, err := strconv.ParseUint(query.Get("page"), 10, 0); err == nil {
+		call.Page = uint(page)
+	}
+	if perPage, err := strconv.ParseUint(query.Get("per_page"), 10, 0); err == nil {
+		call.PerPage = uint(perPage)
+	}
+
+	ctx = c.instrumentation.Start(ctx, call)
+	start := time.Now()
+	res, err := c.send(ctx, req.WithContext(ctx))
+	call.Duration = time.Since(start)
+	call.Err = err
+	if res != nil {
+		call.StatusCode = res.StatusCode
+		call.RateLimit, _ = parseRateLimit(res.Header)
+		if res.StatusCode >= http.StatusBadRequest {
+			call.ErrorType = peekErrorType(res)
+		}
+	}
+	c.instrumentation.End(ctx, call)
+	return res, err
+}
+
+// peekErrorType reads the type of a Qiita error response, leaving the body readable again.
+func peekErrorType(res *http.Response) string {
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+	var body struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(b, &body)
+	return body.Type
+}
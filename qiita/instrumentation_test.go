@@ -0,0 +1,177 @@
+package qiita
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+// An in-memory Instrumentation recording ended calls as spans and their counts and latencies as metrics.
+type recorder struct {
+	mu        sync.Mutex
+	spans     []Call
+	count     map[string]int
+	latencies map[string][]time.Duration
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		count:     map[string]int{},
+		latencies: map[string][]time.Duration{},
+	}
+}
+
+func (r *recorder) Start(ctx context.Context, call *Call) context.Context {
+	return context.WithValue(ctx, spanKey{}, call.Operation)
+}
+
+func (r *recorder) End(ctx context.Context, call *Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ctx.Value(spanKey{}) != call.Operation {
+		panic("End must be called with the context returned by Start")
+	}
+	r.spans = append(r.spans, *call)
+	r.count[call.Operation]++
+	r.latencies[call.Operation] = append(r.latencies[call.Operation], call.Duration)
+}
+
+func TestInstrumentation(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Rate-Limit", "1000")
+		w.Header().Set("Rate-Remaining", "998")
+		w.Header().Set("Rate-Reset", "1445412480")
+		switch r.URL.Path {
+		case "/api/v2/items":
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_items.json")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","type":"not_found"}`))
+		}
+	}))
+	defer server.Close()
+
+	rec := newRecorder()
+	c, _ := mockClient(server)
+	c.instrumentation = rec
+	c.middlewares = []Middleware{func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Context().Value(spanKey{}) == nil {
+				t.Error("request context does not come from Start")
+			}
+			return next.Do(req)
+		})
+	}}
+	ctx := context.TODO()
+
+	if _, _, err := c.ListItems(ctx, 2, 20, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrNotFound)
+	}
+	for _, err := range c.AllItems(ctx, "") {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(rec.spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(rec.spans))
+	}
+	list := rec.spans[0]
+	if list.Operation != "qiita.ListItems" || list.Method != http.MethodGet || list.Path != "/api/v2/items" {
+		t.Errorf("span = %+v", list)
+	}
+	if list.Page != 2 || list.PerPage != 20 || list.StatusCode != http.StatusOK || list.RateLimit.Remaining != 998 {
+		t.Errorf("span = %+v", list)
+	}
+	get := rec.spans[1]
+	if get.Operation != "qiita.GetItem" || get.StatusCode != http.StatusNotFound || get.ErrorType != "not_found" {
+		t.Errorf("span = %+v", get)
+	}
+	if rec.spans[2].Operation != "qiita.ListItems" || rec.spans[2].Page != 1 {
+		t.Errorf("span = %+v", rec.spans[2])
+	}
+	if rec.count["qiita.ListItems"] != 2 || len(rec.latencies["qiita.GetItem"]) != 1 {
+		t.Errorf("count = %v, latencies = %v", rec.count, rec.latencies)
+	}
+}
+
+func TestInstrumentationTransportError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	c, _ := mockClient(server)
+	server.Close()
+
+	rec := newRecorder()
+	c.instrumentation = rec
+	ctx := context.TODO()
+	if err := c.DeleteItem(ctx, "c686397e4a0f4f11683d"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(rec.spans) != 1 || rec.spans[0].Operation != "qiita.DeleteItem" || rec.spans[0].Err == nil || rec.spans[0].StatusCode != 0 {
+		t.Errorf("spans = %+v", rec.spans)
+	}
+}
+
+// Each method passes its own name as the operation of its requests.
+func TestOperationNames(t *testing.T) {
+	names, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	n := 0
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || len(call.Args) < 2 {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "get", "post", "patch", "put", "delete":
+				default:
+					return true
+				}
+				n++
+				if lit, ok := call.Args[1].(*ast.BasicLit); !ok || lit.Value != strconv.Quote(fn.Name.Name) {
+					t.Errorf("%s: %s calls %s with operation %s", fset.Position(call.Pos()), fn.Name.Name, sel.Sel.Name, types.ExprString(call.Args[1]))
+				}
+				return true
+			})
+		}
+	}
+	if n == 0 {
+		t.Error("found no requests")
+	}
+}
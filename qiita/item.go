@@ -4,28 +4,40 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
 
 // Represents an item posted from a user
+//
+// GroupUrlName is only sent, to post an item to a group of Qiita:Team with CreateItem or UpdateItem.
+// Responses have the group in Group instead.
 type Item struct {
-	Body         string    `json:"body"`
-	Coediting    bool      `json:"coediting"`
-	CreatedAt    string    `json:"created_at,omitempty"`
-	Gist         bool      `json:"gist,omitempty"`
-	Group        *Group    `json:"group,omitempty"`
-	Id           string    `json:"id,omitempty"`
-	Private      bool      `json:"private"`
-	Tags         *Taggings `json:"tags"`
-	Title        string    `json:"title"`
-	Tweet        bool      `json:"tweet,omitempty"`
-	RenderedBody string    `json:"rendered_body,omitempty"`
-	UpdatedAt    string    `json:"updated_at,omitempty"`
-	Url          string    `json:"url,omitempty"`
-	User         *User     `json:"user,omitempty"`
+	Body                string          `json:"body"`
+	Coediting           bool            `json:"coediting"`
+	CommentsCount       uint            `json:"comments_count,omitempty"`
+	CreatedAt           Time            `json:"created_at,omitzero"`
+	Gist                bool            `json:"gist,omitempty"`
+	Group               *Group          `json:"group,omitempty"`
+	GroupUrlName        string          `json:"group_url_name,omitempty"`
+	Id                  ItemId          `json:"id,omitempty"`
+	LikesCount          uint            `json:"likes_count,omitempty"`
+	OrganizationUrlName string          `json:"organization_url_name,omitempty"`
+	PageViewsCount      uint            `json:"page_views_count,omitempty"`
+	Private             bool            `json:"private"`
+	ReactionsCount      uint            `json:"reactions_count,omitempty"`
+	Slide               bool            `json:"slide,omitempty"`
+	StocksCount         uint            `json:"stocks_count,omitempty"`
+	Tags                Taggings        `json:"tags"`
+	TeamMembership      *TeamMembership `json:"team_membership,omitempty"`
+	Title               string          `json:"title"`
+	Tweet               bool            `json:"tweet,omitempty"`
+	RenderedBody        string          `json:"rendered_body,omitempty"`
+	UpdatedAt           Time            `json:"updated_at,omitzero"`
+	Url                 string          `json:"url,omitempty"`
+	User                *User           `json:"user,omitempty"`
 }
 
 type Items []Item
@@ -35,23 +47,38 @@ type Items []Item
 
 	GET /api/v2/authenticated_user/items
 */
-func (c *Client) ListAuthenticatedUserItems(ctx context.Context, page, perPage uint) (*Items, error) {
+func (c *Client) ListAuthenticatedUserItems(ctx context.Context, page, perPage uint) (*Items, *Page, error) {
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/authenticated_user/items", &rawQuery)
+	res, err := c.get(ctx, "ListAuthenticatedUserItems", "/api/v2/authenticated_user/items", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var items Items
-	if err := decodeBody(res, &items); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &items); err != nil {
+		return nil, nil, err
 	}
-	return &items, nil
+	return &items, newPage(res), nil
+}
+
+/*
+	Iterate over the authenticated user's items, fetching every page lazily.
+
+	GET /api/v2/authenticated_user/items
+*/
+func (c *Client) AllAuthenticatedUserItems(ctx context.Context) iter.Seq2[Item, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Item, *Page, error) {
+		items, p, err := c.ListAuthenticatedUserItems(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *items, p, nil
+	})
 }
 
 /*
@@ -59,7 +86,7 @@ func (c *Client) ListAuthenticatedUserItems(ctx context.Context, page, perPage u
 
 	GET /api/v2/items
 */
-func (c *Client) ListItems(ctx context.Context, page, perPage uint, query string) (*Items, error) {
+func (c *Client) ListItems(ctx context.Context, page, perPage uint, query string) (*Items, *Page, error) {
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
@@ -67,18 +94,33 @@ func (c *Client) ListItems(ctx context.Context, page, perPage uint, query string
 		values.Add("query", query)
 	}
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/items", &rawQuery)
+	res, err := c.get(ctx, "ListItems", "/api/v2/items", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var items Items
-	if err := decodeBody(res, &items); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &items); err != nil {
+		return nil, nil, err
 	}
-	return &items, nil
+	return &items, newPage(res), nil
+}
+
+/*
+	Iterate over items matching query, fetching every page lazily.
+
+	GET /api/v2/items
+*/
+func (c *Client) AllItems(ctx context.Context, query string) iter.Seq2[Item, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Item, *Page, error) {
+		items, p, err := c.ListItems(ctx, page, perPage, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *items, p, nil
+	})
 }
 
 /*
@@ -86,16 +128,20 @@ func (c *Client) ListItems(ctx context.Context, page, perPage uint, query string
 
 	POST /api/v2/items
 */
-func (c *Client) CreateItem(ctx context.Context, item Item) error {
+func (c *Client) CreateItem(ctx context.Context, item Item) (*Item, error) {
 	b, _ := json.Marshal(item)
-	res, err := c.post(ctx, "/api/v2/items", bytes.NewBuffer(b))
+	res, err := c.post(ctx, "CreateItem", "/api/v2/items", bytes.NewBuffer(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var created Item
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
 /*
@@ -103,14 +149,17 @@ func (c *Client) CreateItem(ctx context.Context, item Item) error {
 
 	DELETE /api/v2/items/:item_id
 */
-func (c *Client) DeleteItem(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s", itemId)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteItem(ctx context.Context, itemId ItemId) error {
+	p, err := apiPath("/api/v2/items/%s", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteItem", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -120,17 +169,20 @@ func (c *Client) DeleteItem(ctx context.Context, itemId string) error {
 
 	GET /api/v2/items/:item_id
 */
-func (c *Client) GetItem(ctx context.Context, itemId string) (*Item, error) {
-	p := fmt.Sprintf("/api/v2/items/%s", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) GetItem(ctx context.Context, itemId ItemId) (*Item, error) {
+	p, err := apiPath("/api/v2/items/%s", itemId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetItem", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var item Item
-	if err := decodeBody(res, &item); err != nil {
+	if err := decodeBody(ctx, res, &item); err != nil {
 		return nil, err
 	}
 	return &item, nil
@@ -141,17 +193,24 @@ func (c *Client) GetItem(ctx context.Context, itemId string) (*Item, error) {
 
 	PATCH /api/v2/items/:item_id
 */
-func (c *Client) UpdateItem(ctx context.Context, item Item) error {
+func (c *Client) UpdateItem(ctx context.Context, item Item) (*Item, error) {
 	b, _ := json.Marshal(item)
-	p := fmt.Sprintf("/api/v2/items/%s", item.Id)
-	res, err := c.patch(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/items/%s", item.Id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateItem", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var updated Item
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
 
 /*
@@ -159,14 +218,20 @@ func (c *Client) UpdateItem(ctx context.Context, item Item) error {
 
 	DELETE /api/v2/items/:item_id/like
 */
-func (c *Client) UnlikeItem(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/like", itemId)
-	res, err := c.delete(ctx, p)
+func (c *Client) UnlikeItem(ctx context.Context, itemId ItemId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/items/%s/like", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "UnlikeItem", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -176,14 +241,20 @@ func (c *Client) UnlikeItem(ctx context.Context, itemId string) error {
 
 	PUT /api/v2/items/:item_id/like
 */
-func (c *Client) LikeItem(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/like", itemId)
-	res, err := c.put(ctx, p, nil)
+func (c *Client) LikeItem(ctx context.Context, itemId ItemId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/items/%s/like", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.put(ctx, "LikeItem", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -193,14 +264,17 @@ func (c *Client) LikeItem(ctx context.Context, itemId string) error {
 
 	PUT /api/v2/items/:item_id/stock
 */
-func (c *Client) StockItem(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/stock", itemId)
-	res, err := c.put(ctx, p, nil)
+func (c *Client) StockItem(ctx context.Context, itemId ItemId) error {
+	p, err := apiPath("/api/v2/items/%s/stock", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.put(ctx, "StockItem", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -210,14 +284,17 @@ func (c *Client) StockItem(ctx context.Context, itemId string) error {
 
 	DELETE /api/v2/items/:item_id/stock
 */
-func (c *Client) UnstockItem(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/stock", itemId)
-	res, err := c.delete(ctx, p)
+func (c *Client) UnstockItem(ctx context.Context, itemId ItemId) error {
+	p, err := apiPath("/api/v2/items/%s/stock", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "UnstockItem", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -227,14 +304,17 @@ func (c *Client) UnstockItem(ctx context.Context, itemId string) error {
 
 	GET /api/v2/items/:item_id/stock
 */
-func (c *Client) EnsureItemStock(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/stock", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) EnsureItemStock(ctx context.Context, itemId ItemId) error {
+	p, err := apiPath("/api/v2/items/%s/stock", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.get(ctx, "EnsureItemStock", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -244,14 +324,20 @@ func (c *Client) EnsureItemStock(ctx context.Context, itemId string) error {
 
 	GET /api/v2/items/:item_id/like
 */
-func (c *Client) EnsureItemLike(ctx context.Context, itemId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/like", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) EnsureItemLike(ctx context.Context, itemId ItemId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/items/%s/like", itemId)
+	if err != nil {
+		return err
+	}
+	res, err := c.get(ctx, "EnsureItemLike", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -261,24 +347,42 @@ func (c *Client) EnsureItemLike(ctx context.Context, itemId string) error {
 
 	GET /api/v2/tags/:tag_id/items
 */
-func (c *Client) ListTaggedItems(ctx context.Context, tagId string, page, perPage uint) (*Items, error) {
-	p := fmt.Sprintf("/api/v2/tags/%s/items", tagId)
+func (c *Client) ListTaggedItems(ctx context.Context, tagId TagId, page, perPage uint) (*Items, *Page, error) {
+	p, err := apiPath("/api/v2/tags/%s/items", tagId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListTaggedItems", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var items Items
-	if err := decodeBody(res, &items); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &items); err != nil {
+		return nil, nil, err
 	}
-	return &items, nil
+	return &items, newPage(res), nil
+}
+
+/*
+	Iterate over tagged items, fetching every page lazily.
+
+	GET /api/v2/tags/:tag_id/items
+*/
+func (c *Client) AllTaggedItems(ctx context.Context, tagId TagId) iter.Seq2[Item, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Item, *Page, error) {
+		items, p, err := c.ListTaggedItems(ctx, tagId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *items, p, nil
+	})
 }
 
 /*
@@ -286,24 +390,42 @@ func (c *Client) ListTaggedItems(ctx context.Context, tagId string, page, perPag
 
 	GET /api/v2/users/:user_id/items
 */
-func (c *Client) ListUserItems(ctx context.Context, userId string, page, perPage uint) (*Items, error) {
-	p := fmt.Sprintf("/api/v2/users/%s/items", userId)
+func (c *Client) ListUserItems(ctx context.Context, userId UserId, page, perPage uint) (*Items, *Page, error) {
+	p, err := apiPath("/api/v2/users/%s/items", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListUserItems", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var items Items
-	if err := decodeBody(res, &items); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &items); err != nil {
+		return nil, nil, err
 	}
-	return &items, nil
+	return &items, newPage(res), nil
+}
+
+/*
+	Iterate over a user's items, fetching every page lazily.
+
+	GET /api/v2/users/:user_id/items
+*/
+func (c *Client) AllUserItems(ctx context.Context, userId UserId) iter.Seq2[Item, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Item, *Page, error) {
+		items, p, err := c.ListUserItems(ctx, userId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *items, p, nil
+	})
 }
 
 /*
@@ -311,22 +433,40 @@ func (c *Client) ListUserItems(ctx context.Context, userId string, page, perPage
 
 	GET /api/v2/users/:user_id/stocks
 */
-func (c *Client) ListUserStocks(ctx context.Context, userId string, page, perPage uint) (*Items, error) {
-	p := fmt.Sprintf("/api/v2/users/%s/stocks", userId)
+func (c *Client) ListUserStocks(ctx context.Context, userId UserId, page, perPage uint) (*Items, *Page, error) {
+	p, err := apiPath("/api/v2/users/%s/stocks", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListUserStocks", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var items Items
-	if err := decodeBody(res, &items); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &items); err != nil {
+		return nil, nil, err
 	}
-	return &items, nil
+	return &items, newPage(res), nil
+}
+
+/*
+	Iterate over a user's stocked items, fetching every page lazily.
+
+	GET /api/v2/users/:user_id/stocks
+*/
+func (c *Client) AllUserStocks(ctx context.Context, userId UserId) iter.Seq2[Item, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Item, *Page, error) {
+		items, p, err := c.ListUserStocks(ctx, userId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *items, p, nil
+	})
 }
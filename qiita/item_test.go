@@ -16,7 +16,7 @@ func TestListAuthenticatedUserItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListAuthenticatedUserItems(ctx, 1, 1)
+		_, _, err := c.ListAuthenticatedUserItems(ctx, 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestListAuthenticatedUserItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListAuthenticatedUserItems(ctx, 1, 1)
+		_, _, err := c.ListAuthenticatedUserItems(ctx, 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +46,7 @@ func TestListItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItems(ctx, 1, 1, "")
+		_, _, err := c.ListItems(ctx, 1, 1, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +60,7 @@ func TestListItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItems(ctx, 1, 1, "")
+		_, _, err := c.ListItems(ctx, 1, 1, "")
 		if err == nil {
 			t.Fail()
 		}
@@ -72,25 +72,28 @@ func TestCreateItem(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusCreated)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_item.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateItem(ctx, Item{})
+		item, err := c.CreateItem(ctx, Item{})
 		if err != nil {
 			t.Fatal(err)
 		}
+		if item.Id != "4bd431809afb1bb99e4f" {
+			t.Errorf("item.Id = %q", item.Id)
+		}
 	}()
 
 	// 400
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_item.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateItem(ctx, Item{})
+		_, err := c.CreateItem(ctx, Item{})
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +109,7 @@ func TestDeleteItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItem(ctx, "")
+		err := c.DeleteItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +123,7 @@ func TestDeleteItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItem(ctx, "")
+		err := c.DeleteItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -136,10 +139,22 @@ func TestGetItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetItem(ctx, "")
+		item, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
+		if item.LikesCount != 100 || item.CommentsCount != 100 || item.ReactionsCount != 100 || item.StocksCount != 100 || item.PageViewsCount != 100 {
+			t.Errorf("counts = %+v", item)
+		}
+		if item.Slide || item.OrganizationUrlName != "increments" || item.TeamMembership == nil || item.TeamMembership.Name != "Hiroshige Umino" {
+			t.Errorf("item = %+v", item)
+		}
+		if len(item.Tags) != 1 || item.Tags[0].Name != "Ruby" {
+			t.Errorf("item.Tags = %+v", item.Tags)
+		}
+		if item.User.ItemsCount != 300 || item.User.TeamOnly {
+			t.Errorf("item.User = %+v", item.User)
+		}
 	}()
 
 	// 400
@@ -150,7 +165,7 @@ func TestGetItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetItem(ctx, "")
+		_, err := c.GetItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -166,7 +181,7 @@ func TestUpdateItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateItem(ctx, Item{})
+		_, err := c.UpdateItem(ctx, Item{Id: "c686397e4a0f4f11683d"})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -180,7 +195,7 @@ func TestUpdateItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateItem(ctx, Item{})
+		_, err := c.UpdateItem(ctx, Item{Id: "c686397e4a0f4f11683d"})
 		if err == nil {
 			t.Fail()
 		}
@@ -196,7 +211,7 @@ func TestUnlikeItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnlikeItem(ctx, "")
+		err := c.UnlikeItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -210,7 +225,7 @@ func TestUnlikeItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnlikeItem(ctx, "")
+		err := c.UnlikeItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -226,7 +241,7 @@ func TestLikeItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.LikeItem(ctx, "")
+		err := c.LikeItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -240,7 +255,7 @@ func TestLikeItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.LikeItem(ctx, "")
+		err := c.LikeItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -256,7 +271,7 @@ func TestStockItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.StockItem(ctx, "")
+		err := c.StockItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -270,7 +285,7 @@ func TestStockItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.StockItem(ctx, "")
+		err := c.StockItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -286,7 +301,7 @@ func TestUnstockItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnstockItem(ctx, "")
+		err := c.UnstockItem(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -300,7 +315,7 @@ func TestUnstockItem(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnstockItem(ctx, "")
+		err := c.UnstockItem(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -316,7 +331,7 @@ func TestEnsureItemStock(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureItemStock(ctx, "")
+		err := c.EnsureItemStock(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -330,7 +345,7 @@ func TestEnsureItemStock(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureItemStock(ctx, "")
+		err := c.EnsureItemStock(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -346,7 +361,7 @@ func TestEnsureItemLike(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureItemLike(ctx, "")
+		err := c.EnsureItemLike(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -360,7 +375,7 @@ func TestEnsureItemLike(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureItemLike(ctx, "")
+		err := c.EnsureItemLike(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
@@ -376,7 +391,7 @@ func TestListTaggedItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTaggedItems(ctx, "", 1, 1)
+		_, _, err := c.ListTaggedItems(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -390,7 +405,7 @@ func TestListTaggedItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTaggedItems(ctx, "", 1, 1)
+		_, _, err := c.ListTaggedItems(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -406,7 +421,7 @@ func TestListUserItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUserItems(ctx, "", 1, 1)
+		_, _, err := c.ListUserItems(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -420,7 +435,7 @@ func TestListUserItems(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUserItems(ctx, "", 1, 1)
+		_, _, err := c.ListUserItems(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -436,7 +451,7 @@ func TestListUserStocks(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUserStocks(ctx, "", 1, 1)
+		_, _, err := c.ListUserStocks(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -450,7 +465,7 @@ func TestListUserStocks(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUserStocks(ctx, "", 1, 1)
+		_, _, err := c.ListUserStocks(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
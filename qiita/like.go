@@ -2,15 +2,13 @@ package qiita
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"net/http"
 )
 
 // Represents a like to an item (only available on Qiita:Team).
 type Like struct {
-	CreatedAt string `json:"created_at"`
-	User      User   `json:"user"`
+	CreatedAt Time `json:"created_at,omitzero"`
+	User      User `json:"user"`
 }
 
 type Likes []Like
@@ -20,17 +18,23 @@ type Likes []Like
 
 	GET /api/v2/items/:item_id/likes
 */
-func (c *Client) ListItemLikes(ctx context.Context, itemId string) (*Likes, error) {
-	p := fmt.Sprintf("/api/v2/items/%s/likes", itemId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) ListItemLikes(ctx context.Context, itemId ItemId) (*Likes, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	p, err := apiPath("/api/v2/items/%s/likes", itemId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "ListItemLikes", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var likes Likes
-	if err := decodeBody(res, &likes); err != nil {
+	if err := decodeBody(ctx, res, &likes); err != nil {
 		return nil, err
 	}
 	return &likes, nil
@@ -16,7 +16,7 @@ func TestListItemLikes(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItemLikes(ctx, "")
+		_, err := c.ListItemLikes(ctx, "c686397e4a0f4f11683d")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestListItemLikes(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListItemLikes(ctx, "")
+		_, err := c.ListItemLikes(ctx, "c686397e4a0f4f11683d")
 		if err == nil {
 			t.Fail()
 		}
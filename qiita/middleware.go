@@ -0,0 +1,88 @@
+package qiita
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sends an HTTP request and returns its response. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Adapts an ordinary function to Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Wraps the Doer which sends a request. Middlewares run on every attempt, including retries,
+// and see the request after the Authorization and User-Agent headers are set.
+type Middleware func(next Doer) Doer
+
+// chain wraps doer with middlewares, the first one being the outermost.
+func chain(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+const redacted = "[REDACTED]"
+
+// Logs each request and its response status and duration to logger.
+// The bearer token in the Authorization header and access tokens in URLs are redacted.
+func NewLoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", redactURL(req)),
+				slog.Any("request_header", redactHeader(req.Header)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+				logger.LogAttrs(req.Context(), slog.LevelError, "qiita request failed", attrs...)
+				return res, err
+			}
+			attrs = append(attrs,
+				slog.Int("status", res.StatusCode),
+				slog.Any("response_header", res.Header),
+			)
+			logger.LogAttrs(req.Context(), slog.LevelInfo, "qiita request", attrs...)
+			return res, err
+		})
+	}
+}
+
+func redactHeader(header http.Header) http.Header {
+	h := header.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", "Bearer "+redacted)
+	}
+	return h
+}
+
+// redactURL hides the token of DELETE /api/v2/access_tokens/:access_token and
+// DELETE /api/v2/team_access_tokens/:access_token.
+func redactURL(req *http.Request) string {
+	u := req.URL.String()
+	for _, prefix := range []string{"/api/v2/access_tokens/", "/api/v2/team_access_tokens/"} {
+		i := strings.Index(u, prefix)
+		if i < 0 {
+			continue
+		}
+		rest := u[i+len(prefix):]
+		if j := strings.IndexAny(rest, "/?#"); j >= 0 {
+			return u[:i+len(prefix)] + redacted + rest[j:]
+		}
+		return u[:i+len(prefix)] + redacted
+	}
+	return u
+}
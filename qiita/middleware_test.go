@@ -0,0 +1,99 @@
+package qiita
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := strings.Join(r.Header.Values("X-Trace"), ","); got != "outer,inner" {
+			t.Errorf("X-Trace = %q, want %q", got, "outer,inner")
+		}
+		w.WriteHeader(http.StatusOK)
+		http.ServeFile(w, r, "testdata/get_item.json")
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Add("X-Trace", name)
+				return next.Do(req)
+			})
+		}
+	}
+	c, _ := mockClient(server)
+	c.middlewares = []Middleware{trace("outer"), trace("inner")}
+	ctx := context.TODO()
+	if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("order = %q", order)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	}))
+	defer server.Close()
+
+	injected := errors.New("injected")
+	c, _ := mockClient(server)
+	c.middlewares = []Middleware{func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, injected
+		})
+	}}
+	ctx := context.TODO()
+	if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err != injected {
+		t.Errorf("err = %v, want %v", err, injected)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.TODO()
+	const token = "ea5d0a593b2655e9568f144fb1826342292f5c6b"
+	for _, tt := range []struct {
+		name   string
+		revoke func(c *Client) error
+		url    string
+	}{
+		{"DeleteAccessToken", func(c *Client) error { return c.DeleteAccessToken(ctx, token) }, "/api/v2/access_tokens/[REDACTED]"},
+		{"RevokeTeamAccessToken", func(c *Client) error { return c.RevokeTeamAccessToken(ctx, token) }, "/api/v2/team_access_tokens/[REDACTED]"},
+	} {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		c, _ := mockClient(server)
+		c.Token = token
+		c.middlewares = []Middleware{NewLoggingMiddleware(logger)}
+		if err := tt.revoke(c); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		log := buf.String()
+		if strings.Contains(log, token) {
+			t.Errorf("%s: log contains the access token: %s", tt.name, log)
+		}
+		for _, want := range []string{`"method":"DELETE"`, `"status":204`, `Bearer [REDACTED]`, tt.url} {
+			if !strings.Contains(log, want) {
+				t.Errorf("%s: log does not contain %s: %s", tt.name, want, log)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package qiita
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Scopes of an access token
+const (
+	ScopeReadQiita      = "read_qiita"
+	ScopeWriteQiita     = "write_qiita"
+	ScopeReadQiitaTeam  = "read_qiita_team"
+	ScopeWriteQiitaTeam = "write_qiita_team"
+)
+
+// Returned from HandleOAuthCallback when the state of a callback does not match the expected one.
+var ErrOAuthState = errors.New("qiita: oauth state mismatch")
+
+// An OAuth application registered on Qiita, used for the authorization code flow.
+type OAuthConfig struct {
+	ClientId     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// Generates a random state to protect the authorization code flow against CSRF.
+// Keep it in the user's session and pass it to both AuthCodeURL and HandleOAuthCallback.
+func NewOAuthState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+/*
+	Build the URL of the authorization page to redirect the user to.
+
+	GET /api/v2/oauth/authorize
+*/
+func (c *Client) AuthCodeURL(config OAuthConfig, state string) string {
+	values := url.Values{}
+	values.Add("client_id", config.ClientId)
+	values.Add("scope", strings.Join(config.Scopes, " "))
+	values.Add("state", state)
+	return c.url("/api/v2/oauth/authorize") + "?" + values.Encode()
+}
+
+// Exchanges an authorization code for an access token.
+func (c *Client) ExchangeOAuthCode(ctx context.Context, config OAuthConfig, code string) (*AccessToken, error) {
+	return c.CreateAccessToken(ctx, Auth{
+		ClientId:     config.ClientId,
+		ClientSecret: config.ClientSecret,
+		Code:         code,
+	})
+}
+
+// Handles the redirect back from the authorization page: verifies its state against the expected one
+// and exchanges its code for an access token.
+func (c *Client) HandleOAuthCallback(ctx context.Context, config OAuthConfig, r *http.Request, state string) (*AccessToken, error) {
+	query := r.URL.Query()
+	if state == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return nil, ErrOAuthState
+	}
+	if e := query.Get("error"); e != "" {
+		return nil, fmt.Errorf("qiita: authorization failed: %s", e)
+	}
+	code := query.Get("code")
+	if code == "" {
+		return nil, errors.New("qiita: authorization code is missing")
+	}
+	return c.ExchangeOAuthCode(ctx, config, code)
+}
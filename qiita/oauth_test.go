@@ -0,0 +1,102 @@
+package qiita
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewOAuthState(t *testing.T) {
+	a, err := NewOAuthState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewOAuthState()
+	if len(a) != 32 || a == b {
+		t.Errorf("states = %q, %q", a, b)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	c, _ := NewClient("", *NewConfig())
+	config := OAuthConfig{
+		ClientId: "a91f0396a0968ff593eafdd194e3d17d32c41b1da7b25e873b42e9058058cd9d",
+		Scopes:   []string{ScopeReadQiita, ScopeWriteQiita},
+	}
+	u, err := url.Parse(c.AuthCodeURL(config, "bb17785d811bb1913ef54b0a7657de780defaa2d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "https" || u.Host != "qiita.com" || u.Path != "/api/v2/oauth/authorize" {
+		t.Errorf("url = %s", u)
+	}
+	query := u.Query()
+	if query.Get("client_id") != config.ClientId || query.Get("scope") != "read_qiita write_qiita" || query.Get("state") != "bb17785d811bb1913ef54b0a7657de780defaa2d" {
+		t.Errorf("query = %v", query)
+	}
+}
+
+func TestHandleOAuthCallback(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Authorization = %q, want none", r.Header.Get("Authorization"))
+		}
+		var auth Auth
+		json.NewDecoder(r.Body).Decode(&auth)
+		if auth != (Auth{ClientId: "id", ClientSecret: "secret", Code: "fefef1111ffe"}) {
+			t.Errorf("auth = %+v", auth)
+		}
+		w.WriteHeader(http.StatusCreated)
+		http.ServeFile(w, r, "testdata/create_access_token.json")
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	config := OAuthConfig{ClientId: "id", ClientSecret: "secret"}
+	ctx := context.TODO()
+
+	// Valid callback
+	r := httptest.NewRequest(http.MethodGet, "/callback?code=fefef1111ffe&state=state", nil)
+	accessToken, err := c.HandleOAuthCallback(ctx, config, r, "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accessToken.Token != "ea5d0a593b2655e9568f144fb1826342292f5c6b" {
+		t.Errorf("accessToken = %+v", accessToken)
+	}
+
+	// State mismatch
+	for _, target := range []string{"/callback?code=fefef1111ffe&state=other", "/callback?code=fefef1111ffe"} {
+		r = httptest.NewRequest(http.MethodGet, target, nil)
+		if _, err := c.HandleOAuthCallback(ctx, config, r, "state"); err != ErrOAuthState {
+			t.Errorf("%s: err = %v, want %v", target, err, ErrOAuthState)
+		}
+	}
+	r = httptest.NewRequest(http.MethodGet, "/callback?code=fefef1111ffe", nil)
+	if _, err := c.HandleOAuthCallback(ctx, config, r, ""); err != ErrOAuthState {
+		t.Errorf("empty state: err = %v, want %v", err, ErrOAuthState)
+	}
+
+	// Denied by the user
+	r = httptest.NewRequest(http.MethodGet, "/callback?error=access_denied&state=state", nil)
+	if _, err := c.HandleOAuthCallback(ctx, config, r, "state"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestHandleOAuthCallbackInvalidCode(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"Bad request","type":"bad_request"}`))
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+	r := httptest.NewRequest(http.MethodGet, "/callback?code=invalid&state=state", nil)
+	if _, err := c.HandleOAuthCallback(ctx, OAuthConfig{}, r, "state"); !errors.Is(err, ErrBadRequest) {
+		t.Errorf("err = %v, want %v", err, ErrBadRequest)
+	}
+}
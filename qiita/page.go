@@ -0,0 +1,92 @@
+package qiita
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// Qiita API v2 rejects page numbers greater than 100 and per_page values greater than 100.
+const (
+	maxPage    = 100
+	maxPerPage = 100
+)
+
+// Pagination information of a list response, parsed from the Link and Total-Count headers.
+// Page numbers are 0 when the corresponding link is absent.
+type Page struct {
+	TotalCount uint
+	First      uint
+	Prev       uint
+	Next       uint
+	Last       uint
+}
+
+var linkPattern = regexp.MustCompile(`<([^>]*)>\s*;\s*rel="?(\w+)"?`)
+
+func newPage(res *http.Response) *Page {
+	var p Page
+	if n, err := strconv.ParseUint(res.Header.Get("Total-Count"), 10, 0); err == nil {
+		p.TotalCount = uint(n)
+	}
+	for _, link := range res.Header.Values("Link") {
+		for _, m := range linkPattern.FindAllStringSubmatch(link, -1) {
+			u, err := url.Parse(m[1])
+			if err != nil {
+				continue
+			}
+			n, err := strconv.ParseUint(u.Query().Get("page"), 10, 0)
+			if err != nil {
+				continue
+			}
+			switch m[2] {
+			case "first":
+				p.First = uint(n)
+			case "prev":
+				p.Prev = uint(n)
+			case "next":
+				p.Next = uint(n)
+			case "last":
+				p.Last = uint(n)
+			}
+		}
+	}
+	return &p
+}
+
+// paginate walks every page returned by list, starting from the first one.
+// It stops at the last page, at Qiita's page cap, or when ctx is done.
+func paginate[T any](ctx context.Context, list func(page, perPage uint) ([]T, *Page, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		page := uint(1)
+		for page <= maxPage {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			values, p, err := list(page, maxPerPage)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, v := range values {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			switch {
+			case p.Next > page:
+				page = p.Next
+			case p.Last == 0 && len(values) == maxPerPage:
+				// No Link header; keep going while pages are full.
+				page++
+			default:
+				return
+			}
+		}
+	}
+}
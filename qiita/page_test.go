@@ -0,0 +1,149 @@
+package qiita
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Total-Count", "6938")
+	res.Header.Set("Link", `<https://qiita.com/api/v2/users?page=1>; rel="first", <https://qiita.com/api/v2/users?page=1>; rel="prev", <https://qiita.com/api/v2/users?page=3>; rel="next", <https://qiita.com/api/v2/users?page=700>; rel="last"`)
+	p := newPage(res)
+	want := Page{TotalCount: 6938, First: 1, Prev: 1, Next: 3, Last: 700}
+	if *p != want {
+		t.Errorf("newPage() = %+v, want %+v", *p, want)
+	}
+
+	p = newPage(&http.Response{Header: http.Header{}})
+	if *p != (Page{}) {
+		t.Errorf("newPage() = %+v, want zero Page", *p)
+	}
+}
+
+func TestListUsersPage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Total-Count", "3")
+		w.Header().Set("Link", `<https://qiita.com/api/v2/users?page=2&per_page=1>; rel="next", <https://qiita.com/api/v2/users?page=3&per_page=1>; rel="last"`)
+		w.WriteHeader(http.StatusOK)
+		http.ServeFile(w, r, "testdata/list_users.json")
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+	_, p, err := c.ListUsers(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.TotalCount != 3 || p.Next != 2 || p.Last != 3 {
+		t.Errorf("page = %+v", *p)
+	}
+}
+
+// Serves total tags split into pages of per_page with Link headers.
+func pagedTagServer(total int, withLink bool) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		last := (total + perPage - 1) / perPage
+		if withLink {
+			link := fmt.Sprintf(`<%s?page=%d>; rel="last"`, r.URL.Path, last)
+			if page < last {
+				link += fmt.Sprintf(`, <%s?page=%d>; rel="next"`, r.URL.Path, page+1)
+			}
+			w.Header().Set("Link", link)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "[")
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			if i > (page-1)*perPage {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":"tag%d"}`, i)
+		}
+		fmt.Fprint(w, "]")
+	}))
+}
+
+func TestAllTags(t *testing.T) {
+	for _, withLink := range []bool{true, false} {
+		server := pagedTagServer(250, withLink)
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		n := 0
+		for tag, err := range c.AllTags(ctx, "count") {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := TagId(fmt.Sprintf("tag%d", n)); tag.Id != want {
+				t.Fatalf("tag.Id = %q, want %q", tag.Id, want)
+			}
+			n++
+		}
+		server.Close()
+		if n != 250 {
+			t.Errorf("withLink=%v: got %d tags, want 250", withLink, n)
+		}
+	}
+}
+
+func TestAllTagsStopsAtPageCap(t *testing.T) {
+	server := pagedTagServer(maxPage*maxPerPage+1, true)
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+	n := 0
+	for _, err := range c.AllTags(ctx, "count") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != maxPage*maxPerPage {
+		t.Errorf("got %d tags, want %d", n, maxPage*maxPerPage)
+	}
+}
+
+func TestAllTagsCanceled(t *testing.T) {
+	server := pagedTagServer(250, true)
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	var last error
+	for _, err := range c.AllTags(ctx, "count") {
+		if err != nil {
+			last = err
+			break
+		}
+		n++
+		if n == maxPerPage {
+			cancel()
+		}
+	}
+	if last != context.Canceled {
+		t.Errorf("err = %v, want %v", last, context.Canceled)
+	}
+	if n != maxPerPage {
+		t.Errorf("got %d tags before cancellation, want %d", n, maxPerPage)
+	}
+}
+
+func TestAllTagsError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+	for _, err := range c.AllTags(ctx, "count") {
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+}
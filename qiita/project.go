@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -14,12 +14,12 @@ import (
 type Project struct {
 	Archived     bool      `json:"archived"`
 	Body         string    `json:"body"`
-	CreatedAt    string    `json:"created_at,omitempty"`
-	Id           uint      `json:"id,omitempty"`
+	CreatedAt    Time      `json:"created_at,omitzero"`
+	Id           ProjectId `json:"id,omitempty"`
 	Name         string    `json:"name"`
 	RenderedBody string    `json:"rendered_body,omitempty"`
 	Tags         *Taggings `json:"tags,omitempty"`
-	UpdatedAt    string    `json:"updated_at,omitempty"`
+	UpdatedAt    Time      `json:"updated_at,omitzero"`
 }
 
 type Projects []Project
@@ -29,23 +29,41 @@ type Projects []Project
 
 	GET /api/v2/projects
 */
-func (c *Client) ListProjects(ctx context.Context, page, perPage uint) (*Projects, error) {
+func (c *Client) ListProjects(ctx context.Context, page, perPage uint) (*Projects, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/projects", &rawQuery)
+	res, err := c.get(ctx, "ListProjects", "/api/v2/projects", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var projects Projects
-	if err := decodeBody(res, &projects); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &projects); err != nil {
+		return nil, nil, err
 	}
-	return &projects, nil
+	return &projects, newPage(res), nil
+}
+
+/*
+	Iterate over projects, fetching every page lazily.
+
+	GET /api/v2/projects
+*/
+func (c *Client) AllProjects(ctx context.Context) iter.Seq2[Project, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Project, *Page, error) {
+		projects, p, err := c.ListProjects(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *projects, p, nil
+	})
 }
 
 /*
@@ -53,16 +71,23 @@ func (c *Client) ListProjects(ctx context.Context, page, perPage uint) (*Project
 
 	POST /api/v2/projects
 */
-func (c *Client) CreateProject(ctx context.Context, project Project) error {
+func (c *Client) CreateProject(ctx context.Context, project Project) (*Project, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(project)
-	res, err := c.post(ctx, "/api/v2/projects", bytes.NewBuffer(b))
+	res, err := c.post(ctx, "CreateProject", "/api/v2/projects", bytes.NewBuffer(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var created Project
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
 /*
@@ -70,14 +95,20 @@ func (c *Client) CreateProject(ctx context.Context, project Project) error {
 
 	DELETE /api/v2/projects/:project_id
 */
-func (c *Client) DeleteProject(ctx context.Context, projectId uint) error {
-	p := fmt.Sprintf("/api/v2/projects/%d", projectId)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteProject(ctx context.Context, projectId ProjectId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/projects/%s", projectId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteProject", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -87,21 +118,23 @@ func (c *Client) DeleteProject(ctx context.Context, projectId uint) error {
 
 	GET /api/v2/projects/:project_id
 */
-func (c *Client) GetProject(ctx context.Context, projectId string, page, perPage uint) (*Project, error) {
-	p := fmt.Sprintf("/api/v2/projects/%s", projectId)
-	values := url.Values{}
-	values.Add("page", fmt.Sprint(page))
-	values.Add("per_page", fmt.Sprint(perPage))
-	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+func (c *Client) GetProject(ctx context.Context, projectId ProjectId) (*Project, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	p, err := apiPath("/api/v2/projects/%s", projectId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetProject", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var project Project
-	if err := decodeBody(res, &project); err != nil {
+	if err := decodeBody(ctx, res, &project); err != nil {
 		return nil, err
 	}
 	return &project, nil
@@ -112,15 +145,25 @@ func (c *Client) GetProject(ctx context.Context, projectId string, page, perPage
 
 	PATCH /api/v2/projects/:project_id
 */
-func (c *Client) UpdateProject(ctx context.Context, project Project) error {
+func (c *Client) UpdateProject(ctx context.Context, project Project) (*Project, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(project)
-	p := fmt.Sprintf("/api/v2/projects/%d", project.Id)
-	res, err := c.patch(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/projects/%s", project.Id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateProject", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var updated Project
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
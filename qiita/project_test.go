@@ -16,7 +16,7 @@ func TestGetProjects(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListProjects(ctx, 1, 1)
+		_, _, err := c.ListProjects(ctx, 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestGetProjects(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListProjects(ctx, 1, 1)
+		_, _, err := c.ListProjects(ctx, 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -42,11 +42,11 @@ func TestCreateProject(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusCreated)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_project.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateProject(ctx, Project{})
+		_, err := c.CreateProject(ctx, Project{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -56,11 +56,11 @@ func TestCreateProject(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_project.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateProject(ctx, Project{})
+		_, err := c.CreateProject(ctx, Project{})
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +106,7 @@ func TestGetProject(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetProject(ctx, "", 1, 1)
+		_, err := c.GetProject(ctx, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +120,7 @@ func TestGetProject(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetProject(ctx, "", 1, 1)
+		_, err := c.GetProject(ctx, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -132,11 +132,11 @@ func TestUpdateProject(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_project.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateProject(ctx, Project{})
+		_, err := c.UpdateProject(ctx, Project{Id: 1})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -146,11 +146,11 @@ func TestUpdateProject(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_project.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateProject(ctx, Project{})
+		_, err := c.UpdateProject(ctx, Project{Id: 1})
 		if err == nil {
 			t.Fail()
 		}
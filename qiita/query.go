@@ -0,0 +1,299 @@
+package qiita
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A search query of items for ListItems and AllItems, built term by term so that values are quoted as
+// needed:
+//
+//	q := NewQuery().Tag("Go").NotTag("beginner").Stocks(GreaterThan, 100).Or().User("qiita")
+//	items, page, err := c.ListItems(ctx, 1, 20, q.String())
+//
+// Terms are matched all together, and Or separates groups of terms of which any may match, as in
+// "tag:Go -tag:beginner stocks:>100 OR user:qiita".
+type Query struct {
+	groups [][]queryTerm
+}
+
+// How a number or a date of a query is compared, e.g. ">" for stocks:>100.
+type Comparison string
+
+const (
+	Equal          Comparison = ""
+	GreaterThan    Comparison = ">"
+	GreaterOrEqual Comparison = ">="
+	LessThan       Comparison = "<"
+	LessOrEqual    Comparison = "<="
+)
+
+// Layout of dates in queries such as created:>=2024-01-01.
+const QueryDateLayout = "2006-01-02"
+
+// A keyword, a phrase or a field of a query, e.g. -tag:Go.
+type queryTerm struct {
+	negated    bool
+	field      string // empty for a keyword
+	comparison Comparison
+	value      string
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+func (q *Query) add(t queryTerm) *Query {
+	if len(q.groups) == 0 {
+		q.groups = append(q.groups, nil)
+	}
+	last := len(q.groups) - 1
+	q.groups[last] = append(q.groups[last], t)
+	return q
+}
+
+// Matches items containing a keyword, or a phrase if it has spaces.
+func (q *Query) Keyword(keyword string) *Query {
+	return q.add(queryTerm{value: keyword})
+}
+
+// Matches items not containing a keyword or a phrase.
+func (q *Query) ExcludeKeyword(keyword string) *Query {
+	return q.add(queryTerm{negated: true, value: keyword})
+}
+
+// Matches items tagged with a tag.
+func (q *Query) Tag(tagId TagId) *Query {
+	return q.add(queryTerm{field: "tag", value: string(tagId)})
+}
+
+// Matches items not tagged with a tag.
+func (q *Query) NotTag(tagId TagId) *Query {
+	return q.add(queryTerm{negated: true, field: "tag", value: string(tagId)})
+}
+
+// Matches items posted by a user.
+func (q *Query) User(userId UserId) *Query {
+	return q.add(queryTerm{field: "user", value: string(userId)})
+}
+
+// Matches items not posted by a user.
+func (q *Query) NotUser(userId UserId) *Query {
+	return q.add(queryTerm{negated: true, field: "user", value: string(userId)})
+}
+
+// Matches items whose title contains a keyword.
+func (q *Query) Title(keyword string) *Query {
+	return q.add(queryTerm{field: "title", value: keyword})
+}
+
+// Matches items whose body contains a keyword.
+func (q *Query) Body(keyword string) *Query {
+	return q.add(queryTerm{field: "body", value: keyword})
+}
+
+// Matches items whose code blocks contain a keyword.
+func (q *Query) Code(keyword string) *Query {
+	return q.add(queryTerm{field: "code", value: keyword})
+}
+
+// Matches items by their number of stocks, e.g. Stocks(GreaterThan, 100) for stocks:>100.
+func (q *Query) Stocks(c Comparison, n uint) *Query {
+	return q.add(queryTerm{field: "stocks", comparison: c, value: strconv.FormatUint(uint64(n), 10)})
+}
+
+// Matches items by the date they were created on, e.g. Created(GreaterOrEqual, t) for created:>=2024-01-01.
+// The date is taken in the location of t.
+func (q *Query) Created(c Comparison, date time.Time) *Query {
+	return q.add(queryTerm{field: "created", comparison: c, value: date.Format(QueryDateLayout)})
+}
+
+// Matches items by the date they were last updated on, as Created.
+func (q *Query) Updated(c Comparison, date time.Time) *Query {
+	return q.add(queryTerm{field: "updated", comparison: c, value: date.Format(QueryDateLayout)})
+}
+
+// Starts another group of terms, matching items which match either the terms before or those after.
+func (q *Query) Or() *Query {
+	if len(q.groups) > 0 && len(q.groups[len(q.groups)-1]) > 0 {
+		q.groups = append(q.groups, nil)
+	}
+	return q
+}
+
+// Formats the query in the search syntax of Qiita.
+func (q *Query) String() string {
+	var groups []string
+	for _, group := range q.groups {
+		if len(group) == 0 {
+			continue
+		}
+		terms := make([]string, len(group))
+		for i, t := range group {
+			terms[i] = t.String()
+		}
+		groups = append(groups, strings.Join(terms, " "))
+	}
+	return strings.Join(groups, " OR ")
+}
+
+func (t queryTerm) String() string {
+	var b strings.Builder
+	if t.negated {
+		b.WriteByte('-')
+	}
+	if t.field == "" {
+		b.WriteString(quoteQueryValue(t.value, t.field))
+		return b.String()
+	}
+	b.WriteString(t.field + ":" + string(t.comparison) + quoteQueryValue(t.value, t.field))
+	return b.String()
+}
+
+// quoteQueryValue quotes a value which would otherwise be read differently, escaping quotes and
+// backslashes.
+func quoteQueryValue(v, field string) string {
+	quote := v == "" || strings.ContainsAny(v, " \t\r\n\"\\")
+	if field == "" {
+		quote = quote || v == "OR" || strings.HasPrefix(v, "-") || strings.Contains(v, ":")
+	} else {
+		quote = quote || strings.HasPrefix(v, "<") || strings.HasPrefix(v, ">")
+	}
+	if !quote {
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
+
+// Parses a query in the search syntax of Qiita, such as `tag:Go -title:"getting started" OR user:qiita`,
+// so that it can be extended with the builder. Its String is the query with canonical spacing and quoting.
+func ParseQuery(s string) (*Query, error) {
+	q := NewQuery()
+	expectTerm := true
+	for i := skipQuerySpaces(s, 0); i < len(s); i = skipQuerySpaces(s, i) {
+		if end := queryTokenEnd(s, i); s[i:end] == "OR" {
+			if expectTerm {
+				return nil, fmt.Errorf("qiita: query %q: OR at offset %d has no terms before it", s, i)
+			}
+			q.groups = append(q.groups, nil)
+			expectTerm = true
+			i = end
+			continue
+		}
+		t, next, err := parseQueryTerm(s, i)
+		if err != nil {
+			return nil, fmt.Errorf("qiita: query %q: %w", s, err)
+		}
+		q.add(t)
+		expectTerm = false
+		i = next
+	}
+	if expectTerm && len(q.groups) > 0 {
+		return nil, fmt.Errorf("qiita: query %q: OR at the end has no terms after it", s)
+	}
+	return q, nil
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func skipQuerySpaces(s string, i int) int {
+	for i < len(s) && isQuerySpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// queryTokenEnd returns the end of the unquoted token starting at i.
+func queryTokenEnd(s string, i int) int {
+	for i < len(s) && !isQuerySpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+func parseQueryTerm(s string, i int) (queryTerm, int, error) {
+	var t queryTerm
+	if s[i] == '-' && i+1 < len(s) && !isQuerySpace(s[i+1]) {
+		t.negated = true
+		i++
+	}
+	if s[i] != '"' {
+		j := i
+		for j < len(s) && (s[j] == '_' || 'a' <= s[j] && s[j] <= 'z' || 'A' <= s[j] && s[j] <= 'Z' || '0' <= s[j] && s[j] <= '9') {
+			j++
+		}
+		if j > i && j < len(s) && s[j] == ':' {
+			t.field = s[i:j]
+			i = j + 1
+			for _, c := range []Comparison{GreaterOrEqual, LessOrEqual, GreaterThan, LessThan} {
+				if strings.HasPrefix(s[i:], string(c)) {
+					t.comparison = c
+					i += len(c)
+					break
+				}
+			}
+		}
+	}
+	start := i
+	var err error
+	if i < len(s) && s[i] == '"' {
+		t.value, i, err = unquoteQueryValue(s, i)
+		if err != nil {
+			return t, 0, err
+		}
+		if i < len(s) && !isQuerySpace(s[i]) {
+			return t, 0, fmt.Errorf("unexpected %q after a quoted value at offset %d", s[i], i)
+		}
+	} else {
+		i = queryTokenEnd(s, i)
+		t.value = s[start:i]
+		if j := strings.IndexByte(t.value, '"'); j >= 0 {
+			return t, 0, fmt.Errorf("unexpected quote at offset %d", start+j)
+		}
+		if t.field != "" && t.value == "" {
+			return t, 0, fmt.Errorf("%s: has no value at offset %d", t.field, start)
+		}
+	}
+	return t, i, t.validate()
+}
+
+// unquoteQueryValue parses the quoted value starting at i, and returns it and the offset after it.
+func unquoteQueryValue(s string, i int) (string, int, error) {
+	var b strings.Builder
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if j+1 < len(s) {
+				j++
+			}
+			b.WriteByte(s[j])
+		case '"':
+			return b.String(), j + 1, nil
+		default:
+			b.WriteByte(s[j])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quote at offset %d", i)
+}
+
+// validate checks the values of the fields with numbers and dates.
+func (t queryTerm) validate() error {
+	switch t.field {
+	case "stocks":
+		if _, err := strconv.ParseUint(t.value, 10, 0); err != nil {
+			return fmt.Errorf("stocks: %q is not a number", t.value)
+		}
+	case "created", "updated":
+		for _, layout := range []string{QueryDateLayout, "2006-01", "2006"} {
+			if _, err := time.Parse(layout, t.value); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %q is not a date such as 2024-01-31", t.field, t.value)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package qiita
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueryString(t *testing.T) {
+	date := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		q    *Query
+		want string
+	}{
+		{NewQuery(), ""},
+		{NewQuery().Tag("Go").NotTag("beginner"), "tag:Go -tag:beginner"},
+		{NewQuery().User("qiita").NotUser("spam"), "user:qiita -user:spam"},
+		{NewQuery().Title("getting started").Body("context").Code("go func"), `title:"getting started" body:context code:"go func"`},
+		{NewQuery().Stocks(GreaterThan, 100), "stocks:>100"},
+		{NewQuery().Stocks(Equal, 0).Stocks(LessOrEqual, 10), "stocks:0 stocks:<=10"},
+		{NewQuery().Created(GreaterOrEqual, date).Updated(LessThan, date), "created:>=2024-01-31 updated:<2024-01-31"},
+		{NewQuery().Tag("Go").Or().Tag("Rust").Stocks(GreaterThan, 10), "tag:Go OR tag:Rust stocks:>10"},
+		{NewQuery().Or().Tag("Go").Or().Or(), "tag:Go"},
+		{NewQuery().Keyword("context").ExcludeKeyword("goroutine leak"), `context -"goroutine leak"`},
+		{NewQuery().Keyword("OR").Keyword("-1").Keyword("C:"), `"OR" "-1" "C:"`},
+		{NewQuery().Keyword(`say "hi"`).Title(`a\b`), `"say \"hi\"" title:"a\\b"`},
+		{NewQuery().Title("").Title(">5"), `title:"" title:">5"`},
+	}
+	for _, tt := range tests {
+		if got := tt.q.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+		parsed, err := ParseQuery(tt.want)
+		if err != nil {
+			t.Errorf("ParseQuery(%s): %v", tt.want, err)
+			continue
+		}
+		if got := parsed.String(); got != tt.want {
+			t.Errorf("ParseQuery(%s).String() = %s", tt.want, got)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *Query
+		out  string
+	}{
+		{"", NewQuery(), ""},
+		{"  tag:Go\t -tag:beginner  ", NewQuery().Tag("Go").NotTag("beginner"), "tag:Go -tag:beginner"},
+		{`title:"getting started" OR user:qiita`, NewQuery().Title("getting started").Or().User("qiita"), `title:"getting started" OR user:qiita`},
+		{`"goroutine leak" -"data race"`, NewQuery().Keyword("goroutine leak").ExcludeKeyword("data race"), `"goroutine leak" -"data race"`},
+		{"stocks:>=5 created:>2024-01 updated:<2025", nil, "stocks:>=5 created:>2024-01 updated:<2025"},
+		{"likes:>10 is:private", nil, "likes:>10 is:private"},
+		{"or - --x", NewQuery().Keyword("or").Keyword("-").ExcludeKeyword("-x"), `or "-" -"-x"`},
+		{`"a\"b"`, NewQuery().Keyword(`a"b`), `"a\"b"`},
+		{"user:foo:bar", NewQuery().User("foo:bar"), "user:foo:bar"},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.in)
+		if err != nil {
+			t.Errorf("ParseQuery(%q): %v", tt.in, err)
+			continue
+		}
+		if tt.want != nil && !reflect.DeepEqual(q, tt.want) {
+			t.Errorf("ParseQuery(%q) = %#v, want %#v", tt.in, q, tt.want)
+		}
+		if got := q.String(); got != tt.out {
+			t.Errorf("ParseQuery(%q).String() = %s, want %s", tt.in, got, tt.out)
+		}
+	}
+
+	// A parsed query can be extended.
+	q, _ := ParseQuery("tag:Go OR tag:Rust")
+	if got, want := q.Stocks(GreaterThan, 10).String(), "tag:Go OR tag:Rust stocks:>10"; got != want {
+		t.Errorf("extended query = %s, want %s", got, want)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, in := range []string{
+		"OR tag:Go",
+		"tag:Go OR",
+		"tag:Go OR OR tag:Rust",
+		`"unterminated`,
+		`title:"a"b`,
+		`foo"bar`,
+		"tag:",
+		"stocks:>many",
+		"created:>=yesterday",
+		"updated:2024-13-01",
+	} {
+		if q, err := ParseQuery(in); err == nil {
+			t.Errorf("ParseQuery(%q) = %s, want an error", in, q)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package qiita
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit state reported by the Rate-Limit, Rate-Remaining and Rate-Reset response headers.
+type RateLimit struct {
+	Limit     uint
+	Remaining uint
+	Reset     time.Time
+}
+
+// Returns the rate limit state of the latest response. It is the zero value until the first response arrives.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+func (c *Client) updateRateLimit(header http.Header) {
+	rl, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = rl
+}
+
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.ParseUint(header.Get("Rate-Limit"), 10, 0)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.ParseUint(header.Get("Rate-Remaining"), 10, 0)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("Rate-Reset"), 10, 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	return RateLimit{
+		Limit:     uint(limit),
+		Remaining: uint(remaining),
+		Reset:     time.Unix(reset, 0),
+	}, true
+}
+
+// waitRateLimit blocks until a request is allowed by the latest known rate limit state,
+// and reserves that request so that concurrent callers do not overrun the limit.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		rl := c.rateLimit
+		if rl.Limit == 0 {
+			c.mu.Unlock()
+			return nil
+		}
+		if rl.Remaining > 0 {
+			c.rateLimit.Remaining--
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+
+		d := time.Until(rl.Reset)
+		if d <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package qiita
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func rateLimitServer(remaining uint, reset time.Time, hits *int32) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("Rate-Limit", "1000")
+		w.Header().Set("Rate-Remaining", fmt.Sprint(remaining))
+		w.Header().Set("Rate-Reset", fmt.Sprint(reset.Unix()))
+		w.WriteHeader(http.StatusOK)
+		http.ServeFile(w, r, "testdata/get_user.json")
+	}))
+}
+
+func TestRateLimit(t *testing.T) {
+	var hits int32
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := rateLimitServer(999, reset, &hits)
+	defer server.Close()
+	c, _ := mockClient(server)
+	ctx := context.TODO()
+
+	if rl := c.RateLimit(); rl != (RateLimit{}) {
+		t.Errorf("RateLimit() = %+v before any request", rl)
+	}
+	if _, err := c.GetUser(ctx, "qiita"); err != nil {
+		t.Fatal(err)
+	}
+	rl := c.RateLimit()
+	if rl.Limit != 1000 || rl.Remaining != 999 || !rl.Reset.Equal(reset) {
+		t.Errorf("RateLimit() = %+v", rl)
+	}
+}
+
+func TestRateLimitConcurrent(t *testing.T) {
+	var hits int32
+	server := rateLimitServer(999, time.Now().Add(time.Hour), &hits)
+	defer server.Close()
+	c, _ := mockClient(server)
+	c.waitOnRateLimit = true
+	ctx := context.TODO()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetUser(ctx, "qiita")
+			c.RateLimit()
+		}()
+	}
+	wg.Wait()
+	if hits != 10 {
+		t.Errorf("server got %d requests, want 10", hits)
+	}
+}
+
+func TestWaitOnRateLimit(t *testing.T) {
+	// Exhausted until the reset an hour later: the second request must wait and give up with ctx.
+	func() {
+		var hits int32
+		server := rateLimitServer(0, time.Now().Add(time.Hour), &hits)
+		defer server.Close()
+		c, _ := mockClient(server)
+		c.waitOnRateLimit = true
+		if _, err := c.GetUser(context.TODO(), "qiita"); err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := c.GetUser(ctx, "qiita"); err != context.DeadlineExceeded {
+			t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if hits != 1 {
+			t.Errorf("server got %d requests, want 1", hits)
+		}
+	}()
+
+	// Exhausted but already reset: requests go through.
+	func() {
+		var hits int32
+		server := rateLimitServer(0, time.Now().Add(-time.Minute), &hits)
+		defer server.Close()
+		c, _ := mockClient(server)
+		c.waitOnRateLimit = true
+		ctx := context.TODO()
+		for i := 0; i < 2; i++ {
+			if _, err := c.GetUser(ctx, "qiita"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if hits != 2 {
+			t.Errorf("server got %d requests, want 2", hits)
+		}
+	}()
+
+	// Without the option, exhausted limits are not enforced on the client side.
+	func() {
+		var hits int32
+		server := rateLimitServer(0, time.Now().Add(time.Hour), &hits)
+		defer server.Close()
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		for i := 0; i < 2; i++ {
+			if _, err := c.GetUser(ctx, "qiita"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if hits != 2 {
+			t.Errorf("server got %d requests, want 2", hits)
+		}
+	}()
+}
@@ -0,0 +1,148 @@
+package qiita
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Policy for retrying failed requests. The zero value disables retries.
+//
+// Requests with idempotent methods (GET, HEAD, OPTIONS, PUT and DELETE) are retried on transport errors
+// and on responses with a status in RetryableStatuses or a 403 caused by an exhausted rate limit.
+// The delay before the next attempt is the exponential backoff, or the time requested by the
+// Retry-After or Rate-Reset header when it is longer. A retry which would not finish before
+// the context deadline is not attempted.
+type RetryPolicy struct {
+	// Number of attempts including the first one. Values less than 2 disable retries.
+	MaxAttempts int
+
+	// Backoff before the second attempt, doubled on each further attempt up to MaxDelay.
+	// The backoff is not capped when MaxDelay is zero.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Randomize each backoff between half and all of its value.
+	Jitter bool
+
+	RetryableStatuses []int
+
+	// Reports whether a request with a non-idempotent method (POST, PATCH) may be retried.
+	// They are never retried when it is nil.
+	RetryNonIdempotent func(req *http.Request) bool
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      true,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, res *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if p.RetryNonIdempotent == nil || !p.RetryNonIdempotent(req) {
+			return false
+		}
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	if res.StatusCode == http.StatusForbidden && res.Header.Get("Rate-Remaining") == "0" {
+		return true
+	}
+	return slices.Contains(p.RetryableStatuses, res.StatusCode)
+}
+
+// delay returns the time to wait before the next attempt after the given attempt failed.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+	d := p.BaseDelay << (attempt - 1)
+	if attempt > 63 || d>>(attempt-1) != p.BaseDelay || d > maxDelay {
+		// The backoff overflowed or exceeds the cap.
+		d = maxDelay
+	}
+	if p.Jitter && d > 1 {
+		d = d/2 + rand.N(d/2)
+	}
+	if res != nil {
+		if after := retryAfter(res.Header); after > d {
+			d = after
+		}
+	}
+	return d
+}
+
+// retryAfter returns the wait requested by the Retry-After header, or by Rate-Reset once
+// the rate limit is exhausted.
+func retryAfter(header http.Header) time.Duration {
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t)
+		}
+	}
+	if header.Get("Rate-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("Rate-Reset"), 10, 64); err == nil {
+			return time.Until(time.Unix(reset, 0))
+		}
+	}
+	return 0
+}
+
+// sleep waits for d unless ctx is done first or its deadline comes before d elapses.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// rewind prepares req to be sent again.
+func rewind(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+func discard(res *http.Response) {
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
@@ -0,0 +1,234 @@
+package qiita
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func retryClient(server *httptest.Server) *Client {
+	c, _ := mockClient(server)
+	c.retryPolicy = *NewRetryPolicy()
+	c.retryPolicy.BaseDelay = time.Millisecond
+	c.retryPolicy.MaxDelay = 10 * time.Millisecond
+	return c
+}
+
+// Responds with the given statuses in order, then with the fixture and the last status, recording request bodies.
+func flakyServer(fixture string, bodies *[]string, statuses ...int) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(b))
+		if n := len(*bodies); n < len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(statuses[len(statuses)-1])
+		http.ServeFile(w, r, fixture)
+	}))
+}
+
+func TestRetry(t *testing.T) {
+	// Idempotent requests are retried until they succeed.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/get_item.json", &bodies, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+		defer server.Close()
+		c := retryClient(server)
+		ctx := context.TODO()
+		if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+			t.Fatal(err)
+		}
+		if len(bodies) != 3 {
+			t.Errorf("server got %d requests, want 3", len(bodies))
+		}
+	}()
+
+	// Retries give up after MaxAttempts.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/get_item.json", &bodies, 503, 503, 503, 200)
+		defer server.Close()
+		c := retryClient(server)
+		ctx := context.TODO()
+		if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err == nil {
+			t.Fatal("expected an error")
+		}
+		if len(bodies) != 3 {
+			t.Errorf("server got %d requests, want 3", len(bodies))
+		}
+	}()
+
+	// Statuses outside RetryableStatuses are not retried.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/get_item.json", &bodies, http.StatusNotFound, http.StatusOK)
+		defer server.Close()
+		c := retryClient(server)
+		ctx := context.TODO()
+		if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("err = %v, want %v", err, ErrNotFound)
+		}
+		if len(bodies) != 1 {
+			t.Errorf("server got %d requests, want 1", len(bodies))
+		}
+	}()
+
+	// Without a policy, requests are sent once.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/get_item.json", &bodies, http.StatusServiceUnavailable, http.StatusOK)
+		defer server.Close()
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err == nil {
+			t.Fatal("expected an error")
+		}
+		if len(bodies) != 1 {
+			t.Errorf("server got %d requests, want 1", len(bodies))
+		}
+	}()
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	// POST is not retried by default.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/create_item.json", &bodies, http.StatusServiceUnavailable, http.StatusCreated)
+		defer server.Close()
+		c := retryClient(server)
+		ctx := context.TODO()
+		if _, err := c.CreateItem(ctx, Item{Title: "title"}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if len(bodies) != 1 {
+			t.Errorf("server got %d requests, want 1", len(bodies))
+		}
+	}()
+
+	// POST is retried when opted in, with the body sent again.
+	func() {
+		var bodies []string
+		server := flakyServer("testdata/create_item.json", &bodies, http.StatusServiceUnavailable, http.StatusCreated)
+		defer server.Close()
+		c := retryClient(server)
+		c.retryPolicy.RetryNonIdempotent = func(req *http.Request) bool {
+			return req.URL.Path == "/api/v2/items"
+		}
+		ctx := context.TODO()
+		if _, err := c.CreateItem(ctx, Item{Title: "title"}); err != nil {
+			t.Fatal(err)
+		}
+		if len(bodies) != 2 {
+			t.Fatalf("server got %d requests, want 2", len(bodies))
+		}
+		if bodies[0] == "" || bodies[0] != bodies[1] {
+			t.Errorf("bodies = %q", bodies)
+		}
+	}()
+}
+
+func TestRetryTransportError(t *testing.T) {
+	n := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		http.ServeFile(w, r, "testdata/get_item.json")
+	}))
+	defer server.Close()
+	c := retryClient(server)
+	ctx := context.TODO()
+	if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("server got %d requests, want 2", n)
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	var bodies []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, "")
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c := retryClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.GetItem(ctx, "c686397e4a0f4f11683d"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("GetItem took %v waiting for a retry past the deadline", elapsed)
+	}
+	if len(bodies) != 1 {
+		t.Errorf("server got %d requests, want 1", len(bodies))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt int
+		header  http.Header
+		want    time.Duration
+	}{
+		{1, http.Header{}, 100 * time.Millisecond},
+		{2, http.Header{}, 200 * time.Millisecond},
+		{5, http.Header{}, time.Second},
+		{64, http.Header{}, time.Second},
+		{1, http.Header{"Retry-After": {"5"}}, 5 * time.Second},
+		{1, http.Header{"Retry-After": {"0"}}, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		res := &http.Response{Header: tt.header}
+		if got := p.delay(tt.attempt, res); got != tt.want {
+			t.Errorf("delay(%d, %v) = %v, want %v", tt.attempt, tt.header, got, tt.want)
+		}
+	}
+
+	reset := http.Header{
+		"Rate-Remaining": {"0"},
+		"Rate-Reset":     {strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)},
+	}
+	if got := p.delay(1, &http.Response{Header: reset}); got < 58*time.Second || got > time.Minute {
+		t.Errorf("delay with Rate-Reset = %v, want about a minute", got)
+	}
+
+	p.Jitter = true
+	for i := 0; i < 100; i++ {
+		if got := p.delay(2, &http.Response{Header: http.Header{}}); got < 100*time.Millisecond || got >= 200*time.Millisecond {
+			t.Fatalf("delay with jitter = %v, want in [100ms, 200ms)", got)
+		}
+	}
+
+	// Without MaxDelay, the backoff keeps doubling.
+	p = RetryPolicy{BaseDelay: time.Second}
+	for _, tt := range []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{4, 8 * time.Second},
+		{11, 1024 * time.Second},
+		{64, math.MaxInt64},
+	} {
+		if got := p.delay(tt.attempt, &http.Response{Header: http.Header{}}); got != tt.want {
+			t.Errorf("delay(%d) without MaxDelay = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package qiita
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// Items, likes and taggings on items, implemented by *Client.
+type ItemsService interface {
+	ListAuthenticatedUserItems(ctx context.Context, page, perPage uint) (*Items, *Page, error)
+	AllAuthenticatedUserItems(ctx context.Context) iter.Seq2[Item, error]
+	ListItems(ctx context.Context, page, perPage uint, query string) (*Items, *Page, error)
+	AllItems(ctx context.Context, query string) iter.Seq2[Item, error]
+	CreateItem(ctx context.Context, item Item) (*Item, error)
+	DeleteItem(ctx context.Context, itemId ItemId) error
+	GetItem(ctx context.Context, itemId ItemId) (*Item, error)
+	UpdateItem(ctx context.Context, item Item) (*Item, error)
+	UnlikeItem(ctx context.Context, itemId ItemId) error
+	LikeItem(ctx context.Context, itemId ItemId) error
+	StockItem(ctx context.Context, itemId ItemId) error
+	UnstockItem(ctx context.Context, itemId ItemId) error
+	EnsureItemStock(ctx context.Context, itemId ItemId) error
+	EnsureItemLike(ctx context.Context, itemId ItemId) error
+	ListTaggedItems(ctx context.Context, tagId TagId, page, perPage uint) (*Items, *Page, error)
+	AllTaggedItems(ctx context.Context, tagId TagId) iter.Seq2[Item, error]
+	ListUserItems(ctx context.Context, userId UserId, page, perPage uint) (*Items, *Page, error)
+	AllUserItems(ctx context.Context, userId UserId) iter.Seq2[Item, error]
+	ListUserStocks(ctx context.Context, userId UserId, page, perPage uint) (*Items, *Page, error)
+	AllUserStocks(ctx context.Context, userId UserId) iter.Seq2[Item, error]
+	ListItemLikes(ctx context.Context, itemId ItemId) (*Likes, error)
+	AddItemTagging(ctx context.Context, itemId ItemId, tagging Tagging) (*Tagging, error)
+	DeleteItemTagging(ctx context.Context, itemId ItemId, taggingId string) error
+}
+
+// Users, their stockers and follows, implemented by *Client.
+type UsersService interface {
+	GetAuthenticatedUser(ctx context.Context) (*AuthenticatedUser, error)
+	ListStockers(ctx context.Context, itemId ItemId, page, perPage uint) (*Users, *Page, error)
+	AllStockers(ctx context.Context, itemId ItemId) iter.Seq2[User, error]
+	ListUsers(ctx context.Context, page, perPage uint) (*Users, *Page, error)
+	AllUsers(ctx context.Context) iter.Seq2[User, error]
+	GetUser(ctx context.Context, userId UserId) (*User, error)
+	ListFollowees(ctx context.Context, userId UserId, page, perPage uint) (*Users, *Page, error)
+	AllFollowees(ctx context.Context, userId UserId) iter.Seq2[User, error]
+	ListFollowers(ctx context.Context, userId UserId, page, perPage uint) (*Users, *Page, error)
+	AllFollowers(ctx context.Context, userId UserId) iter.Seq2[User, error]
+	UnfollowUser(ctx context.Context, userId UserId) error
+	EnsureFollowingUser(ctx context.Context, userId UserId) error
+	FollowUser(ctx context.Context, userId UserId) error
+}
+
+// Tags and tag follows, implemented by *Client.
+type TagsService interface {
+	ListTags(ctx context.Context, page, perPage uint, sort string) (*Tags, *Page, error)
+	AllTags(ctx context.Context, sort string) iter.Seq2[Tag, error]
+	GetTag(ctx context.Context, tagId TagId) (*Tag, error)
+	ListFollowingTags(ctx context.Context, userId UserId, page, perPage uint) (*Tags, *Page, error)
+	AllFollowingTags(ctx context.Context, userId UserId) iter.Seq2[Tag, error]
+	UnfollowTag(ctx context.Context, tagId TagId) error
+	EnsureFollowingTag(ctx context.Context, tagId TagId) error
+	FollowTag(ctx context.Context, tagId TagId) error
+}
+
+// Comments on items and projects, implemented by *Client.
+type CommentsService interface {
+	DeleteComment(ctx context.Context, commentId CommentId) error
+	GetComment(ctx context.Context, commentId CommentId) (*Comment, error)
+	UpdateComment(ctx context.Context, comment Comment) (*Comment, error)
+	ListComments(ctx context.Context, itemId ItemId) (*Comments, error)
+	PostComment(ctx context.Context, itemId ItemId, comment Comment) (*Comment, error)
+	ListProjectComments(ctx context.Context, projectId ProjectId, page, perPage uint) (*Comments, *Page, error)
+	AllProjectComments(ctx context.Context, projectId ProjectId) iter.Seq2[Comment, error]
+	PostProjectComment(ctx context.Context, projectId ProjectId, comment Comment) (*Comment, error)
+}
+
+// Emoji reactions on comments, items and projects, implemented by *Client.
+type ReactionsService interface {
+	AddCommentReaction(ctx context.Context, commentId CommentId, reaction Reaction) (*Reaction, error)
+	AddItemReaction(ctx context.Context, itemId ItemId, reaction Reaction) (*Reaction, error)
+	AddProjectReaction(ctx context.Context, projectId ProjectId, reaction Reaction) (*Reaction, error)
+	DeleteCommentReaction(ctx context.Context, commentId CommentId, reactionName string) error
+	DeleteItemReaction(ctx context.Context, itemId ItemId, reactionName string) error
+	DeleteProjectReaction(ctx context.Context, projectId ProjectId, reactionName string) error
+	ListCommentReactions(ctx context.Context, commentId CommentId) (*Reactions, error)
+	ListItemReactions(ctx context.Context, itemId ItemId) (*Reactions, error)
+	ListProjectReactions(ctx context.Context, projectId ProjectId) (*Reactions, error)
+}
+
+// Projects on Qiita:Team, implemented by *Client.
+type ProjectsService interface {
+	ListProjects(ctx context.Context, page, perPage uint) (*Projects, *Page, error)
+	AllProjects(ctx context.Context) iter.Seq2[Project, error]
+	CreateProject(ctx context.Context, project Project) (*Project, error)
+	DeleteProject(ctx context.Context, projectId ProjectId) error
+	GetProject(ctx context.Context, projectId ProjectId) (*Project, error)
+	UpdateProject(ctx context.Context, project Project) (*Project, error)
+}
+
+// Templates on Qiita:Team, implemented by *Client.
+type TemplatesService interface {
+	ListTemplates(ctx context.Context, page, perPage uint) (*Templates, *Page, error)
+	AllTemplates(ctx context.Context) iter.Seq2[Template, error]
+	DeleteTemplate(ctx context.Context, templateId TemplateId) error
+	GetTemplate(ctx context.Context, templateId TemplateId) (*Template, error)
+	CreateTemplate(ctx context.Context, template Template) (*Template, error)
+	UpdateTemplate(ctx context.Context, template Template) (*Template, error)
+	CreateExpandedTemplate(ctx context.Context, template Template) (*ExpandedTemplate, error)
+}
+
+// Groups and their members on Qiita:Team, implemented by *Client.
+type GroupsService interface {
+	ListGroups(ctx context.Context, page, perPage uint) (*Groups, *Page, error)
+	AllGroups(ctx context.Context) iter.Seq2[Group, error]
+	GetGroup(ctx context.Context, urlName string) (*Group, error)
+	CreateGroup(ctx context.Context, group Group) (*Group, error)
+	UpdateGroup(ctx context.Context, group Group) (*Group, error)
+	DeleteGroup(ctx context.Context, urlName string) error
+	ListGroupMembers(ctx context.Context, urlName string, page, perPage uint) (*GroupMembers, *Page, error)
+	AllGroupMembers(ctx context.Context, urlName string) iter.Seq2[GroupMember, error]
+	AddGroupMember(ctx context.Context, urlName string, member GroupMember) (*GroupMember, error)
+	UpdateGroupMember(ctx context.Context, urlName string, member GroupMember) (*GroupMember, error)
+	RemoveGroupMember(ctx context.Context, urlName string, userId UserId) error
+}
+
+// Teams, team membership, invitations and team access tokens, implemented by *Client.
+type TeamService interface {
+	ListTeams(ctx context.Context) (*Teams, error)
+	GetTeamMembership(ctx context.Context) (*TeamMembership, error)
+	ListTeamInvitations(ctx context.Context, page, perPage uint) (*TeamInvitations, *Page, error)
+	AllTeamInvitations(ctx context.Context) iter.Seq2[TeamInvitation, error]
+	CreateTeamInvitation(ctx context.Context, invitation TeamInvitation) (*TeamInvitation, error)
+	RevokeTeamInvitation(ctx context.Context, email string) error
+	ListTeamAccessTokens(ctx context.Context, page, perPage uint) (*TeamAccessTokens, *Page, error)
+	AllTeamAccessTokens(ctx context.Context) iter.Seq2[TeamAccessToken, error]
+	CreateTeamAccessToken(ctx context.Context, token TeamAccessToken) (*TeamAccessToken, error)
+	RevokeTeamAccessToken(ctx context.Context, accessToken string) error
+}
+
+// Access tokens and the OAuth authorization code flow, implemented by *Client.
+type AccessTokensService interface {
+	CreateAccessToken(ctx context.Context, auth Auth) (*AccessToken, error)
+	DeleteAccessToken(ctx context.Context, accessToken string) error
+	AuthCodeURL(config OAuthConfig, state string) string
+	ExchangeOAuthCode(ctx context.Context, config OAuthConfig, code string) (*AccessToken, error)
+	HandleOAuthCallback(ctx context.Context, config OAuthConfig, r *http.Request, state string) (*AccessToken, error)
+}
+
+// The whole Qiita API v2, implemented by *Client. Depend on it, or on one of the services it
+// consists of, to substitute a fake such as qiitamock.Client in tests.
+type API interface {
+	ItemsService
+	UsersService
+	TagsService
+	CommentsService
+	ReactionsService
+	ProjectsService
+	TemplatesService
+	GroupsService
+	TeamService
+	AccessTokensService
+}
+
+var _ API = (*Client)(nil)
@@ -2,8 +2,8 @@ package qiita
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -12,7 +12,7 @@ import (
 type Tag struct {
 	FollowersCount uint   `json:"followers_count"`
 	IconUrl        string `json:"icon_url"`
-	Id             string `json:"id"`
+	Id             TagId  `json:"id"`
 	ItemsCount     uint   `json:"items_count"`
 }
 
@@ -23,24 +23,39 @@ type Tags []Tag
 
 	GET /api/v2/tags
 */
-func (c *Client) ListTags(ctx context.Context, page, perPage uint, sort string) (*Tags, error) {
+func (c *Client) ListTags(ctx context.Context, page, perPage uint, sort string) (*Tags, *Page, error) {
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	values.Add("sort", sort)
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/tags", &rawQuery)
+	res, err := c.get(ctx, "ListTags", "/api/v2/tags", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var tags Tags
-	if err := decodeBody(res, &tags); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &tags); err != nil {
+		return nil, nil, err
 	}
-	return &tags, nil
+	return &tags, newPage(res), nil
+}
+
+/*
+	Iterate over tags, fetching every page lazily.
+
+	GET /api/v2/tags
+*/
+func (c *Client) AllTags(ctx context.Context, sort string) iter.Seq2[Tag, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Tag, *Page, error) {
+		tags, p, err := c.ListTags(ctx, page, perPage, sort)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *tags, p, nil
+	})
 }
 
 /*
@@ -48,17 +63,20 @@ func (c *Client) ListTags(ctx context.Context, page, perPage uint, sort string)
 
 	GET /api/v2/tags/:tag_id
 */
-func (c *Client) GetTag(ctx context.Context, tagId string) (*Tag, error) {
-	p := fmt.Sprintf("/api/v2/tags/%s", tagId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) GetTag(ctx context.Context, tagId TagId) (*Tag, error) {
+	p, err := apiPath("/api/v2/tags/%s", tagId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetTag", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var tag Tag
-	if err := decodeBody(res, &tag); err != nil {
+	if err := decodeBody(ctx, res, &tag); err != nil {
 		return nil, err
 	}
 	return &tag, nil
@@ -69,24 +87,42 @@ func (c *Client) GetTag(ctx context.Context, tagId string) (*Tag, error) {
 
 	GET /api/v2/users/:user_id/following_tags
 */
-func (c *Client) ListFollowingTags(ctx context.Context, userId string, page, perPage uint) (*Tags, error) {
-	p := fmt.Sprintf("/api/v2/users/%s/following_tags", userId)
+func (c *Client) ListFollowingTags(ctx context.Context, userId UserId, page, perPage uint) (*Tags, *Page, error) {
+	p, err := apiPath("/api/v2/users/%s/following_tags", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListFollowingTags", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var tags Tags
-	if err := decodeBody(res, &tags); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &tags); err != nil {
+		return nil, nil, err
 	}
-	return &tags, nil
+	return &tags, newPage(res), nil
+}
+
+/*
+	Iterate over tags a user is following, fetching every page lazily.
+
+	GET /api/v2/users/:user_id/following_tags
+*/
+func (c *Client) AllFollowingTags(ctx context.Context, userId UserId) iter.Seq2[Tag, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Tag, *Page, error) {
+		tags, p, err := c.ListFollowingTags(ctx, userId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *tags, p, nil
+	})
 }
 
 /*
@@ -94,14 +130,17 @@ func (c *Client) ListFollowingTags(ctx context.Context, userId string, page, per
 
 	DELETE /api/v2/tags/:tag_id/following
 */
-func (c *Client) UnfollowTag(ctx context.Context, tagId string) error {
-	p := fmt.Sprintf("/api/v2/tags/%s/following", tagId)
-	res, err := c.delete(ctx, p)
+func (c *Client) UnfollowTag(ctx context.Context, tagId TagId) error {
+	p, err := apiPath("/api/v2/tags/%s/following", tagId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "UnfollowTag", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -111,14 +150,17 @@ func (c *Client) UnfollowTag(ctx context.Context, tagId string) error {
 
 	GET /api/v2/tags/:tag_id/following
 */
-func (c *Client) EnsureFollowingTag(ctx context.Context, tagId string) error {
-	p := fmt.Sprintf("/api/v2/tags/%s/following", tagId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) EnsureFollowingTag(ctx context.Context, tagId TagId) error {
+	p, err := apiPath("/api/v2/tags/%s/following", tagId)
+	if err != nil {
+		return err
+	}
+	res, err := c.get(ctx, "EnsureFollowingTag", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -128,14 +170,17 @@ func (c *Client) EnsureFollowingTag(ctx context.Context, tagId string) error {
 
 	PUT /api/v2/tags/:tag_id/following
 */
-func (c *Client) FollowTag(ctx context.Context, tagId string) error {
-	p := fmt.Sprintf("/api/v2/tags/%s/following", tagId)
-	res, err := c.put(ctx, p, nil)
+func (c *Client) FollowTag(ctx context.Context, tagId TagId) error {
+	p, err := apiPath("/api/v2/tags/%s/following", tagId)
+	if err != nil {
+		return err
+	}
+	res, err := c.put(ctx, "FollowTag", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
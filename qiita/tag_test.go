@@ -2,6 +2,7 @@ package qiita
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,7 +17,7 @@ func TestListTags(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTags(ctx, 1, 1, "")
+		_, _, err := c.ListTags(ctx, 1, 1, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +31,7 @@ func TestListTags(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTags(ctx, 1, 1, "")
+		_, _, err := c.ListTags(ctx, 1, 1, "")
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +47,7 @@ func TestGetTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetTag(ctx, "")
+		_, err := c.GetTag(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +61,7 @@ func TestGetTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetTag(ctx, "")
+		_, err := c.GetTag(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -76,7 +77,7 @@ func TestListFollowingTags(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowingTags(ctx, "", 1, 1)
+		_, _, err := c.ListFollowingTags(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -90,7 +91,7 @@ func TestListFollowingTags(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowingTags(ctx, "", 1, 1)
+		_, _, err := c.ListFollowingTags(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +107,7 @@ func TestUnfollowTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnfollowTag(ctx, "")
+		err := c.UnfollowTag(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +121,7 @@ func TestUnfollowTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnfollowTag(ctx, "")
+		err := c.UnfollowTag(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -136,7 +137,7 @@ func TestEnsureFollowingTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureFollowingTag(ctx, "")
+		err := c.EnsureFollowingTag(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -150,7 +151,7 @@ func TestEnsureFollowingTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureFollowingTag(ctx, "")
+		err := c.EnsureFollowingTag(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -166,7 +167,7 @@ func TestFollowTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.FollowTag(ctx, "")
+		err := c.FollowTag(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -180,9 +181,74 @@ func TestFollowTag(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.FollowTag(ctx, "")
+		err := c.FollowTag(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
 	}()
 }
+
+// Tag names are escaped as a single path segment, and dot segments are rejected before any request.
+func TestTagIdEscaped(t *testing.T) {
+	tests := []struct {
+		tagId TagId
+		path  string
+	}{
+		{"C#", "/api/v2/tags/C%23"},
+		{".NET", "/api/v2/tags/.NET"},
+		{"c++", "/api/v2/tags/c++"},
+		{"C/C++", "/api/v2/tags/C%2FC++"},
+		{"100%", "/api/v2/tags/100%25"},
+		{"what?", "/api/v2/tags/what%3F"},
+		{"日本語", "/api/v2/tags/%E6%97%A5%E6%9C%AC%E8%AA%9E"},
+		{"..", ""},
+		{".", ""},
+		{"", ""},
+	}
+	calls := []struct {
+		name   string
+		suffix string
+		call   func(c *Client, tagId TagId) error
+	}{
+		{"ListTaggedItems", "/items", func(c *Client, tagId TagId) error {
+			_, _, err := c.ListTaggedItems(context.TODO(), tagId, 1, 1)
+			return err
+		}},
+		{"GetTag", "", func(c *Client, tagId TagId) error {
+			_, err := c.GetTag(context.TODO(), tagId)
+			return err
+		}},
+		{"FollowTag", "/following", func(c *Client, tagId TagId) error {
+			return c.FollowTag(context.TODO(), tagId)
+		}},
+	}
+	for _, call := range calls {
+		for _, tt := range tests {
+			var got string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.EscapedPath()
+				if r.Method == http.MethodPut {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Write([]byte("[]"))
+			}))
+			c, _ := mockClient(server)
+			err := call.call(c, tt.tagId)
+			server.Close()
+			if tt.path == "" {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Errorf("%s(%q): err = %v, want a *ValidationError", call.name, tt.tagId, err)
+				}
+				if got != "" {
+					t.Errorf("%s(%q): unexpected request to %s", call.name, tt.tagId, got)
+				}
+				continue
+			}
+			if want := tt.path + call.suffix; got != want {
+				t.Errorf("%s(%q): path = %q, want %q", call.name, tt.tagId, got, want)
+			}
+		}
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 )
 
@@ -22,17 +20,27 @@ type Taggings []Tagging
 
 	POST /api/v2/items/:item_id/taggings
 */
-func (c *Client) AddItemTagging(ctx context.Context, itemId string, tagging Tagging) error {
+func (c *Client) AddItemTagging(ctx context.Context, itemId ItemId, tagging Tagging) (*Tagging, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(tagging)
-	p := fmt.Sprintf("/api/v2/items/%s/taggings", itemId)
-	res, err := c.post(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/items/%s/taggings", itemId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.post(ctx, "AddItemTagging", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var added Tagging
+	if err := decodeBody(ctx, res, &added); err != nil {
+		return nil, err
+	}
+	return &added, nil
 }
 
 /*
@@ -40,14 +48,20 @@ func (c *Client) AddItemTagging(ctx context.Context, itemId string, tagging Tagg
 
 	DELETE /api/v2/items/:item_id/taggings/:tagging_id
 */
-func (c *Client) DeleteItemTagging(ctx context.Context, itemId, taggingId string) error {
-	p := fmt.Sprintf("/api/v2/items/%s/taggings/%s", itemId, taggingId)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteItemTagging(ctx context.Context, itemId ItemId, taggingId string) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/items/%s/taggings/%s", itemId, param("tagging id", taggingId))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteItemTagging", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
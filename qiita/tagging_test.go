@@ -12,11 +12,11 @@ func TestAddItemTagging(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusCreated)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/add_item_tagging.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddItemTagging(ctx, "", Tagging{})
+		_, err := c.AddItemTagging(ctx, "c686397e4a0f4f11683d", Tagging{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -26,11 +26,11 @@ func TestAddItemTagging(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/add_item_tagging.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.AddItemTagging(ctx, "", Tagging{})
+		_, err := c.AddItemTagging(ctx, "c686397e4a0f4f11683d", Tagging{})
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +46,7 @@ func TestDeleteItemTagging(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItemTagging(ctx, "", "")
+		err := c.DeleteItemTagging(ctx, "c686397e4a0f4f11683d", "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +60,7 @@ func TestDeleteItemTagging(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.DeleteItemTagging(ctx, "", "")
+		err := c.DeleteItemTagging(ctx, "c686397e4a0f4f11683d", "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -1,9 +1,13 @@
 package qiita
 
 import (
+	"bytes"
 	"context"
-	"errors"
+	"encoding/json"
+	"fmt"
+	"iter"
 	"net/http"
+	"net/url"
 )
 
 // Represents a team on Qiita:Team (only available on Qiita:Team).
@@ -15,22 +19,247 @@ type Team struct {
 
 type Teams []Team
 
+// Represents a user belonging to a team (only available on Qiita:Team).
+// Items only have the name of their author's membership.
+type TeamMembership struct {
+	Email string `json:"email,omitempty"`
+	Id    UserId `json:"id,omitempty"`
+	Name  string `json:"name"`
+}
+
+// Represents an invitation to a team (only available on Qiita:Team).
+type TeamInvitation struct {
+	Email string `json:"email"`
+	Url   string `json:"url,omitempty"`
+}
+
+type TeamInvitations []TeamInvitation
+
+// Access token for a team, issued by its administrator (only available on Qiita:Team).
+type TeamAccessToken struct {
+	Scopes []string `json:"scopes"`
+	Token  string   `json:"token,omitempty"`
+}
+
+type TeamAccessTokens []TeamAccessToken
+
 /*
 	List teams the user belongs to in newest order.
 
 	GET /api/v2/teams
 */
 func (c *Client) ListTeams(ctx context.Context) (*Teams, error) {
-	res, err := c.get(ctx, "/api/v2/teams", nil)
+	res, err := c.get(ctx, "ListTeams", "/api/v2/teams", nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var teams Teams
-	if err := decodeBody(res, &teams); err != nil {
+	if err := decodeBody(ctx, res, &teams); err != nil {
 		return nil, err
 	}
 	return &teams, nil
 }
+
+/*
+	Get the membership of the current user in the team (only available on Qiita:Team).
+
+	GET /api/v2/team_membership
+*/
+func (c *Client) GetTeamMembership(ctx context.Context) (*TeamMembership, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetTeamMembership", "/api/v2/team_membership", nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+	var membership TeamMembership
+	if err := decodeBody(ctx, res, &membership); err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+/*
+	List pending invitations to the team in newest order (only available on Qiita:Team).
+
+	GET /api/v2/team_invitations
+*/
+func (c *Client) ListTeamInvitations(ctx context.Context, page, perPage uint) (*TeamInvitations, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
+	values := url.Values{}
+	values.Add("page", fmt.Sprint(page))
+	values.Add("per_page", fmt.Sprint(perPage))
+	rawQuery := values.Encode()
+	res, err := c.get(ctx, "ListTeamInvitations", "/api/v2/team_invitations", &rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(res)
+	}
+	var invitations TeamInvitations
+	if err := decodeBody(ctx, res, &invitations); err != nil {
+		return nil, nil, err
+	}
+	return &invitations, newPage(res), nil
+}
+
+/*
+	Iterate over pending invitations to the team, fetching every page lazily (only available on Qiita:Team).
+
+	GET /api/v2/team_invitations
+*/
+func (c *Client) AllTeamInvitations(ctx context.Context) iter.Seq2[TeamInvitation, error] {
+	return paginate(ctx, func(page, perPage uint) ([]TeamInvitation, *Page, error) {
+		invitations, p, err := c.ListTeamInvitations(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *invitations, p, nil
+	})
+}
+
+/*
+	Invite a user to the team by email (only available on Qiita:Team).
+
+	POST /api/v2/team_invitations
+*/
+func (c *Client) CreateTeamInvitation(ctx context.Context, invitation TeamInvitation) (*TeamInvitation, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(invitation)
+	res, err := c.post(ctx, "CreateTeamInvitation", "/api/v2/team_invitations", bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(res)
+	}
+	var created TeamInvitation
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+/*
+	Revoke a pending invitation to the team (only available on Qiita:Team).
+
+	DELETE /api/v2/team_invitations/:email
+*/
+func (c *Client) RevokeTeamInvitation(ctx context.Context, email string) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/team_invitations/%s", param("email", email))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "RevokeTeamInvitation", p)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+	return nil
+}
+
+/*
+	List access tokens issued for the team (only available on Qiita:Team).
+
+	GET /api/v2/team_access_tokens
+*/
+func (c *Client) ListTeamAccessTokens(ctx context.Context, page, perPage uint) (*TeamAccessTokens, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
+	values := url.Values{}
+	values.Add("page", fmt.Sprint(page))
+	values.Add("per_page", fmt.Sprint(perPage))
+	rawQuery := values.Encode()
+	res, err := c.get(ctx, "ListTeamAccessTokens", "/api/v2/team_access_tokens", &rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(res)
+	}
+	var tokens TeamAccessTokens
+	if err := decodeBody(ctx, res, &tokens); err != nil {
+		return nil, nil, err
+	}
+	return &tokens, newPage(res), nil
+}
+
+/*
+	Iterate over access tokens issued for the team, fetching every page lazily (only available on Qiita:Team).
+
+	GET /api/v2/team_access_tokens
+*/
+func (c *Client) AllTeamAccessTokens(ctx context.Context) iter.Seq2[TeamAccessToken, error] {
+	return paginate(ctx, func(page, perPage uint) ([]TeamAccessToken, *Page, error) {
+		tokens, p, err := c.ListTeamAccessTokens(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *tokens, p, nil
+	})
+}
+
+/*
+	Issue a new access token for the team (only available on Qiita:Team).
+
+	POST /api/v2/team_access_tokens
+*/
+func (c *Client) CreateTeamAccessToken(ctx context.Context, token TeamAccessToken) (*TeamAccessToken, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(token)
+	res, err := c.post(ctx, "CreateTeamAccessToken", "/api/v2/team_access_tokens", bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, newAPIError(res)
+	}
+	var created TeamAccessToken
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+/*
+	Revoke an access token of the team (only available on Qiita:Team).
+
+	DELETE /api/v2/team_access_tokens/:access_token
+*/
+func (c *Client) RevokeTeamAccessToken(ctx context.Context, accessToken string) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/team_access_tokens/%s", param("access token", accessToken))
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "RevokeTeamAccessToken", p)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+	return nil
+}
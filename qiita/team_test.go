@@ -36,3 +36,216 @@ func TestListTeams(t *testing.T) {
 		}
 	}()
 }
+
+func TestGetTeamMembership(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/get_team_membership.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.GetTeamMembership(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/get_team_membership.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.GetTeamMembership(ctx)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestListTeamInvitations(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_team_invitations.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListTeamInvitations(ctx, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/list_team_invitations.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListTeamInvitations(ctx, 1, 1)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestCreateTeamInvitation(t *testing.T) {
+	// 201
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/create_team_invitation.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.CreateTeamInvitation(ctx, TeamInvitation{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/create_team_invitation.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.CreateTeamInvitation(ctx, TeamInvitation{})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestRevokeTeamInvitation(t *testing.T) {
+	// 204
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RevokeTeamInvitation(ctx, "yaotti@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RevokeTeamInvitation(ctx, "yaotti@example.com")
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestListTeamAccessTokens(t *testing.T) {
+	// 200
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			http.ServeFile(w, r, "testdata/list_team_access_tokens.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListTeamAccessTokens(ctx, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/list_team_access_tokens.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, _, err := c.ListTeamAccessTokens(ctx, 1, 1)
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestCreateTeamAccessToken(t *testing.T) {
+	// 201
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			http.ServeFile(w, r, "testdata/create_team_access_token.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		token, err := c.CreateTeamAccessToken(ctx, TeamAccessToken{Scopes: []string{ScopeReadQiitaTeam, ScopeWriteQiitaTeam}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Token != "ea5d0a593b2655e9568f144fb1826342292f5c6b" || len(token.Scopes) != 2 {
+			t.Errorf("token = %+v", token)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "testdata/create_team_access_token.json")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		_, err := c.CreateTeamAccessToken(ctx, TeamAccessToken{})
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
+
+func TestRevokeTeamAccessToken(t *testing.T) {
+	// 204
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RevokeTeamAccessToken(ctx, "ea5d0a593b2655e9568f144fb1826342292f5c6b")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// 400
+	func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			http.ServeFile(w, r, "")
+		}))
+		c, _ := mockClient(server)
+		ctx := context.TODO()
+		err := c.RevokeTeamAccessToken(ctx, "ea5d0a593b2655e9568f144fb1826342292f5c6b")
+		if err == nil {
+			t.Fail()
+		}
+	}()
+}
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -13,11 +13,11 @@ import (
 // Represents a template for generating an item boilerplate (only available on Qiita:Team).
 type Template struct {
 	*ExpandedTemplate
-	Body  string    `json:"body"`
-	Id    uint      `json:"id,omitempty"`
-	Name  string    `json:"name"`
-	Tags  *Taggings `json:"tags"`
-	Title string    `json:"title"`
+	Body  string     `json:"body"`
+	Id    TemplateId `json:"id,omitempty"`
+	Name  string     `json:"name"`
+	Tags  *Taggings  `json:"tags"`
+	Title string     `json:"title"`
 }
 
 type Templates []Template
@@ -27,23 +27,41 @@ type Templates []Template
 
 	GET /api/v2/templates
 */
-func (c *Client) ListTemplates(ctx context.Context, page, perPage uint) (*Templates, error) {
+func (c *Client) ListTemplates(ctx context.Context, page, perPage uint) (*Templates, *Page, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/templates", &rawQuery)
+	res, err := c.get(ctx, "ListTemplates", "/api/v2/templates", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var templates Templates
-	if err := decodeBody(res, &templates); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &templates); err != nil {
+		return nil, nil, err
 	}
-	return &templates, nil
+	return &templates, newPage(res), nil
+}
+
+/*
+	Iterate over templates in a team, fetching every page lazily.
+
+	GET /api/v2/templates
+*/
+func (c *Client) AllTemplates(ctx context.Context) iter.Seq2[Template, error] {
+	return paginate(ctx, func(page, perPage uint) ([]Template, *Page, error) {
+		templates, p, err := c.ListTemplates(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *templates, p, nil
+	})
 }
 
 /*
@@ -51,14 +69,20 @@ func (c *Client) ListTemplates(ctx context.Context, page, perPage uint) (*Templa
 
 	DELETE /api/v2/templates/:template_id
 */
-func (c *Client) DeleteTemplate(ctx context.Context, templateId uint) error {
-	p := fmt.Sprintf("/api/v2/templates/%d", templateId)
-	res, err := c.delete(ctx, p)
+func (c *Client) DeleteTemplate(ctx context.Context, templateId TemplateId) error {
+	if err := c.teamOnly(); err != nil {
+		return err
+	}
+	p, err := apiPath("/api/v2/templates/%s", templateId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "DeleteTemplate", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -68,17 +92,23 @@ func (c *Client) DeleteTemplate(ctx context.Context, templateId uint) error {
 
 	GET /api/v2/templates/:template_id
 */
-func (c *Client) GetTemplate(ctx context.Context, templateId uint) (*Template, error) {
-	p := fmt.Sprintf("/api/v2/templates/%d", templateId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) GetTemplate(ctx context.Context, templateId TemplateId) (*Template, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
+	p, err := apiPath("/api/v2/templates/%s", templateId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetTemplate", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var template Template
-	if err := decodeBody(res, &template); err != nil {
+	if err := decodeBody(ctx, res, &template); err != nil {
 		return nil, err
 	}
 	return &template, nil
@@ -89,16 +119,23 @@ func (c *Client) GetTemplate(ctx context.Context, templateId uint) (*Template, e
 
 	POST /api/v2/templates
 */
-func (c *Client) CreateTemplate(ctx context.Context, template Template) error {
+func (c *Client) CreateTemplate(ctx context.Context, template Template) (*Template, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(template)
-	res, err := c.post(ctx, "/api/v2/templates", bytes.NewBuffer(b))
+	res, err := c.post(ctx, "CreateTemplate", "/api/v2/templates", bytes.NewBuffer(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.StatusCode != http.StatusCreated {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var created Template
+	if err := decodeBody(ctx, res, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
 }
 
 /*
@@ -106,15 +143,25 @@ func (c *Client) CreateTemplate(ctx context.Context, template Template) error {
 
 	PATCH /api/v2/templates/:template_id
 */
-func (c *Client) UpdateTemplate(ctx context.Context, template Template) error {
+func (c *Client) UpdateTemplate(ctx context.Context, template Template) (*Template, error) {
+	if err := c.teamOnly(); err != nil {
+		return nil, err
+	}
 	b, _ := json.Marshal(template)
-	p := fmt.Sprintf("/api/v2/templates/%d", template.Id)
-	res, err := c.patch(ctx, p, bytes.NewBuffer(b))
+	p, err := apiPath("/api/v2/templates/%s", template.Id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	res, err := c.patch(ctx, "UpdateTemplate", p, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
-	return nil
+	var updated Template
+	if err := decodeBody(ctx, res, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
@@ -16,7 +16,7 @@ func TestListTemplates(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTemplates(ctx, 1, 1)
+		_, _, err := c.ListTemplates(ctx, 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestListTemplates(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListTemplates(ctx, 1, 1)
+		_, _, err := c.ListTemplates(ctx, 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -102,11 +102,11 @@ func TestCreateTemplate(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusCreated)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_template.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateTemplate(ctx, Template{})
+		_, err := c.CreateTemplate(ctx, Template{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -116,11 +116,11 @@ func TestCreateTemplate(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/create_template.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.CreateTemplate(ctx, Template{})
+		_, err := c.CreateTemplate(ctx, Template{})
 		if err == nil {
 			t.Fail()
 		}
@@ -132,11 +132,11 @@ func TestUpdateTemplate(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_template.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateTemplate(ctx, Template{})
+		_, err := c.UpdateTemplate(ctx, Template{Id: 1})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -146,11 +146,11 @@ func TestUpdateTemplate(t *testing.T) {
 	func() {
 		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			http.ServeFile(w, r, "")
+			http.ServeFile(w, r, "testdata/update_template.json")
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UpdateTemplate(ctx, Template{})
+		_, err := c.UpdateTemplate(ctx, Template{Id: 1})
 		if err == nil {
 			t.Fail()
 		}
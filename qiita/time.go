@@ -0,0 +1,40 @@
+package qiita
+
+import (
+	"bytes"
+	"time"
+)
+
+// Layout of timestamps in Qiita API v2, which always have a numeric offset such as +09:00.
+const TimeLayout = "2006-01-02T15:04:05-07:00"
+
+// A timestamp of Qiita API v2. It keeps the offset it was given in, so that it is marshaled
+// back exactly as received, and it is zero for empty or null values.
+type Time struct {
+	time.Time
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	b := make([]byte, 0, len(TimeLayout)+2)
+	b = append(b, '"')
+	b = t.AppendFormat(b, TimeLayout)
+	return append(b, '"'), nil
+}
+
+func (t *Time) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) || bytes.Equal(b, []byte(`""`)) {
+		t.Time = time.Time{}
+		return nil
+	}
+	return t.Time.UnmarshalJSON(b)
+}
+
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimeLayout)
+}
@@ -0,0 +1,131 @@
+package qiita
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		out  string
+		zero bool
+	}{
+		{`"2015-09-25T00:00:00+09:00"`, `"2015-09-25T00:00:00+09:00"`, false},
+		{`"2000-01-01T00:00:00+00:00"`, `"2000-01-01T00:00:00+00:00"`, false},
+		{`"2000-01-01T00:00:00Z"`, `"2000-01-01T00:00:00+00:00"`, false},
+		{`""`, `null`, true},
+		{`null`, `null`, true},
+	}
+	for _, tt := range tests {
+		var v Time
+		if err := json.Unmarshal([]byte(tt.in), &v); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if v.IsZero() != tt.zero {
+			t.Errorf("Unmarshal(%s).IsZero() = %v", tt.in, v.IsZero())
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("Marshal(%s): %v", tt.in, err)
+			continue
+		}
+		if string(b) != tt.out {
+			t.Errorf("Marshal(Unmarshal(%s)) = %s, want %s", tt.in, b, tt.out)
+		}
+	}
+
+	var v Time
+	if err := json.Unmarshal([]byte(`"2015/09/25"`), &v); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestTimeOffset(t *testing.T) {
+	var v Time
+	if err := json.Unmarshal([]byte(`"2015-09-25T00:00:00+09:00"`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := v.Zone(); offset != 9*60*60 {
+		t.Errorf("offset = %d, want %d", offset, 9*60*60)
+	}
+	if !v.Equal(time.Date(2015, 9, 24, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("time = %v", v)
+	}
+	if v.String() != "2015-09-25T00:00:00+09:00" {
+		t.Errorf("String() = %q", v.String())
+	}
+}
+
+func TestTimeOmitted(t *testing.T) {
+	b, err := json.Marshal(Comment{Body: "body"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	json.Unmarshal(b, &m)
+	if _, ok := m["created_at"]; ok {
+		t.Errorf("zero created_at is marshaled: %s", b)
+	}
+}
+
+// Timestamps in the fixtures survive decoding into the models and encoding back.
+func TestTimeFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		v       interface{}
+	}{
+		{"testdata/get_item.json", &Item{}},
+		{"testdata/list_items.json", &Items{}},
+		{"testdata/get_comment.json", &Comment{}},
+		{"testdata/get_project.json", &Project{}},
+		{"testdata/list_item_likes.json", &Likes{}},
+		{"testdata/list_item_reactions.json", &Reactions{}},
+	}
+	for _, tt := range tests {
+		b, err := os.ReadFile(tt.fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, tt.v); err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		out, err := json.Marshal(tt.v)
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		var want, got interface{}
+		json.Unmarshal(b, &want)
+		json.Unmarshal(out, &got)
+		wantTimes, gotTimes := timestamps(want, nil), timestamps(got, nil)
+		if len(wantTimes) == 0 || !reflect.DeepEqual(gotTimes, wantTimes) {
+			t.Errorf("%s: timestamps = %v, want %v", tt.fixture, gotTimes, wantTimes)
+		}
+	}
+}
+
+// timestamps collects created_at and updated_at values in v in a stable order.
+func timestamps(v interface{}, acc []string) []string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, key := range []string{"created_at", "updated_at"} {
+			if s, ok := v[key].(string); ok {
+				acc = append(acc, key+"="+s)
+			}
+		}
+		for _, key := range []string{"group", "user"} {
+			acc = timestamps(v[key], acc)
+		}
+	case []interface{}:
+		for _, e := range v {
+			acc = timestamps(e, acc)
+		}
+	}
+	return acc
+}
@@ -2,8 +2,8 @@ package qiita
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -15,14 +15,15 @@ type User struct {
 	FolloweesCount    uint   `json:"followees_count"`
 	FollowersCount    uint   `json:"followers_count"`
 	GitHubLoginName   string `json:"github_login_name"`
-	Id                string `json:"id"`
-	ItemCount         uint   `json:"item_count"`
+	Id                UserId `json:"id"`
+	ItemsCount        uint   `json:"items_count"`
 	LinkedinId        string `json:"linkedin_id"`
 	Location          string `json:"location"`
 	Name              string `json:"name"`
 	Organization      string `json:"organization"`
 	PermanentId       uint   `json:"permanent_id"`
 	ProfileImageUrl   string `json:"profile_image_url"`
+	TeamOnly          bool   `json:"team_only"`
 	TwitterScreenName string `json:"twitter_screen_name"`
 	WebsiteUrl        string `json:"website_url"`
 }
@@ -34,24 +35,42 @@ type Users []User
 
 	GET /api/v2/items/:item_id/stockers
 */
-func (c *Client) ListStockers(ctx context.Context, itemId string, page, perPage uint) (*Users, error) {
-	p := fmt.Sprintf("/api/v2/items/%s/stockers", itemId)
+func (c *Client) ListStockers(ctx context.Context, itemId ItemId, page, perPage uint) (*Users, *Page, error) {
+	p, err := apiPath("/api/v2/items/%s/stockers", itemId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListStockers", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var users Users
-	if err := decodeBody(res, &users); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &users); err != nil {
+		return nil, nil, err
 	}
-	return &users, nil
+	return &users, newPage(res), nil
+}
+
+/*
+	Iterate over users who stocked an item, fetching every page lazily.
+
+	GET /api/v2/items/:item_id/stockers
+*/
+func (c *Client) AllStockers(ctx context.Context, itemId ItemId) iter.Seq2[User, error] {
+	return paginate(ctx, func(page, perPage uint) ([]User, *Page, error) {
+		users, p, err := c.ListStockers(ctx, itemId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *users, p, nil
+	})
 }
 
 /*
@@ -59,23 +78,38 @@ func (c *Client) ListStockers(ctx context.Context, itemId string, page, perPage
 
 	GET /api/v2/users
 */
-func (c *Client) ListUsers(ctx context.Context, page, perPage uint) (*Users, error) {
+func (c *Client) ListUsers(ctx context.Context, page, perPage uint) (*Users, *Page, error) {
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, "/api/v2/users", &rawQuery)
+	res, err := c.get(ctx, "ListUsers", "/api/v2/users", &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var users Users
-	if err := decodeBody(res, &users); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &users); err != nil {
+		return nil, nil, err
 	}
-	return &users, nil
+	return &users, newPage(res), nil
+}
+
+/*
+	Iterate over users, fetching every page lazily.
+
+	GET /api/v2/users
+*/
+func (c *Client) AllUsers(ctx context.Context) iter.Seq2[User, error] {
+	return paginate(ctx, func(page, perPage uint) ([]User, *Page, error) {
+		users, p, err := c.ListUsers(ctx, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *users, p, nil
+	})
 }
 
 /*
@@ -83,17 +117,20 @@ func (c *Client) ListUsers(ctx context.Context, page, perPage uint) (*Users, err
 
 	GET /api/v2/users/:user_id
 */
-func (c *Client) GetUser(ctx context.Context, userId string) (*User, error) {
-	p := fmt.Sprintf("/api/v2/users/%s", userId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) GetUser(ctx context.Context, userId UserId) (*User, error) {
+	p, err := apiPath("/api/v2/users/%s", userId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, "GetUser", p, nil)
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, newAPIError(res)
 	}
 	var user User
-	if err := decodeBody(res, &user); err != nil {
+	if err := decodeBody(ctx, res, &user); err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -104,24 +141,42 @@ func (c *Client) GetUser(ctx context.Context, userId string) (*User, error) {
 
 	GET /api/v2/users/:user_id/followees
 */
-func (c *Client) ListFollowees(ctx context.Context, userId string, page, perPage uint) (*Users, error) {
-	p := fmt.Sprintf("/api/v2/users/%s/followees", userId)
+func (c *Client) ListFollowees(ctx context.Context, userId UserId, page, perPage uint) (*Users, *Page, error) {
+	p, err := apiPath("/api/v2/users/%s/followees", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListFollowees", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var users Users
-	if err := decodeBody(res, &users); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &users); err != nil {
+		return nil, nil, err
 	}
-	return &users, nil
+	return &users, newPage(res), nil
+}
+
+/*
+	Iterate over users a user is following, fetching every page lazily.
+
+	GET /api/v2/users/:user_id/followees
+*/
+func (c *Client) AllFollowees(ctx context.Context, userId UserId) iter.Seq2[User, error] {
+	return paginate(ctx, func(page, perPage uint) ([]User, *Page, error) {
+		users, p, err := c.ListFollowees(ctx, userId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *users, p, nil
+	})
 }
 
 /*
@@ -129,24 +184,42 @@ func (c *Client) ListFollowees(ctx context.Context, userId string, page, perPage
 
 	GET /api/v2/users/:user_id/followers
 */
-func (c *Client) ListFollowers(ctx context.Context, userId string, page, perPage uint) (*Users, error) {
-	p := fmt.Sprintf("/api/v2/users/%s/followers", userId)
+func (c *Client) ListFollowers(ctx context.Context, userId UserId, page, perPage uint) (*Users, *Page, error) {
+	p, err := apiPath("/api/v2/users/%s/followers", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 	values := url.Values{}
 	values.Add("page", fmt.Sprint(page))
 	values.Add("per_page", fmt.Sprint(perPage))
 	rawQuery := values.Encode()
-	res, err := c.get(ctx, p, &rawQuery)
+	res, err := c.get(ctx, "ListFollowers", p, &rawQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, errors.New(res.Status)
+		return nil, nil, newAPIError(res)
 	}
 	var users Users
-	if err := decodeBody(res, &users); err != nil {
-		return nil, err
+	if err := decodeBody(ctx, res, &users); err != nil {
+		return nil, nil, err
 	}
-	return &users, nil
+	return &users, newPage(res), nil
+}
+
+/*
+	Iterate over users who are following a user, fetching every page lazily.
+
+	GET /api/v2/users/:user_id/followers
+*/
+func (c *Client) AllFollowers(ctx context.Context, userId UserId) iter.Seq2[User, error] {
+	return paginate(ctx, func(page, perPage uint) ([]User, *Page, error) {
+		users, p, err := c.ListFollowers(ctx, userId, page, perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *users, p, nil
+	})
 }
 
 /*
@@ -154,14 +227,17 @@ func (c *Client) ListFollowers(ctx context.Context, userId string, page, perPage
 
 	DELETE /api/v2/users/:user_id/following
 */
-func (c *Client) UnfollowUser(ctx context.Context, userId string) error {
-	p := fmt.Sprintf("/api/v2/users/%s/following", userId)
-	res, err := c.delete(ctx, p)
+func (c *Client) UnfollowUser(ctx context.Context, userId UserId) error {
+	p, err := apiPath("/api/v2/users/%s/following", userId)
+	if err != nil {
+		return err
+	}
+	res, err := c.delete(ctx, "UnfollowUser", p)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -171,14 +247,17 @@ func (c *Client) UnfollowUser(ctx context.Context, userId string) error {
 
 	GET /api/v2/users/:user_id/following
 */
-func (c *Client) EnsureFollowingUser(ctx context.Context, userId string) error {
-	p := fmt.Sprintf("/api/v2/users/%s/following", userId)
-	res, err := c.get(ctx, p, nil)
+func (c *Client) EnsureFollowingUser(ctx context.Context, userId UserId) error {
+	p, err := apiPath("/api/v2/users/%s/following", userId)
+	if err != nil {
+		return err
+	}
+	res, err := c.get(ctx, "EnsureFollowingUser", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -188,14 +267,17 @@ func (c *Client) EnsureFollowingUser(ctx context.Context, userId string) error {
 
 	PUT /api/v2/users/:user_id/following
 */
-func (c *Client) FollowUser(ctx context.Context, userId string) error {
-	p := fmt.Sprintf("/api/v2/users/%s/following", userId)
-	res, err := c.put(ctx, p, nil)
+func (c *Client) FollowUser(ctx context.Context, userId UserId) error {
+	p, err := apiPath("/api/v2/users/%s/following", userId)
+	if err != nil {
+		return err
+	}
+	res, err := c.put(ctx, "FollowUser", p, nil)
 	if err != nil {
 		return err
 	}
 	if res.StatusCode != http.StatusNoContent {
-		return errors.New(res.Status)
+		return newAPIError(res)
 	}
 	return nil
 }
@@ -16,7 +16,7 @@ func TestListStockers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListStockers(ctx, "", 1, 1)
+		_, _, err := c.ListStockers(ctx, "c686397e4a0f4f11683d", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +30,7 @@ func TestListStockers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListStockers(ctx, "", 1, 1)
+		_, _, err := c.ListStockers(ctx, "c686397e4a0f4f11683d", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -46,7 +46,7 @@ func TestListUsers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUsers(ctx, 1, 1)
+		_, _, err := c.ListUsers(ctx, 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -60,7 +60,7 @@ func TestListUsers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListUsers(ctx, 1, 1)
+		_, _, err := c.ListUsers(ctx, 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -76,7 +76,7 @@ func TestGetUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetUser(ctx, "")
+		_, err := c.GetUser(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -90,7 +90,7 @@ func TestGetUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.GetUser(ctx, "")
+		_, err := c.GetUser(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -106,7 +106,7 @@ func TestListFollowees(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowees(ctx, "", 1, 1)
+		_, _, err := c.ListFollowees(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,7 +120,7 @@ func TestListFollowees(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowees(ctx, "", 1, 1)
+		_, _, err := c.ListFollowees(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -136,7 +136,7 @@ func TestListFollowers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowers(ctx, "", 1, 1)
+		_, _, err := c.ListFollowers(ctx, "qiita", 1, 1)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -150,7 +150,7 @@ func TestListFollowers(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		_, err := c.ListFollowers(ctx, "", 1, 1)
+		_, _, err := c.ListFollowers(ctx, "qiita", 1, 1)
 		if err == nil {
 			t.Fail()
 		}
@@ -166,7 +166,7 @@ func TestUnfollowUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnfollowUser(ctx, "")
+		err := c.UnfollowUser(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -180,7 +180,7 @@ func TestUnfollowUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.UnfollowUser(ctx, "")
+		err := c.UnfollowUser(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -196,7 +196,7 @@ func TestEnsureFollowingUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureFollowingUser(ctx, "")
+		err := c.EnsureFollowingUser(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -210,7 +210,7 @@ func TestEnsureFollowingUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.EnsureFollowingUser(ctx, "")
+		err := c.EnsureFollowingUser(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
@@ -226,7 +226,7 @@ func TestFollowUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.FollowUser(ctx, "")
+		err := c.FollowUser(ctx, "qiita")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -240,7 +240,7 @@ func TestFollowUser(t *testing.T) {
 		}))
 		c, _ := mockClient(server)
 		ctx := context.TODO()
-		err := c.FollowUser(ctx, "")
+		err := c.FollowUser(ctx, "qiita")
 		if err == nil {
 			t.Fail()
 		}
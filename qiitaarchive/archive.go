@@ -0,0 +1,160 @@
+// Package qiitaarchive exports everything of a Qiita account to a portable archive.
+//
+// An archive is a directory of JSON Lines files, one per kind of resource, with the body of each item
+// also saved as a Markdown file which qiitasync can synchronize:
+//
+//	manifest.json            what the archive contains, see Manifest
+//	checkpoint.json          progress of an unfinished export
+//	user.json                the authenticated user
+//	items.jsonl              items of the user
+//	items/<item id>.md       bodies of the items with front matter
+//	comments.jsonl           comments on the items, as CommentRecord
+//	comment_reactions.jsonl  emoji reactions on the comments, as ReactionRecord
+//	item_reactions.jsonl     emoji reactions on the items, as ReactionRecord
+//	likes.jsonl              likes of the items on Qiita:Team, as LikeRecord
+//	stocks.jsonl             items the user stocked
+//	following_tags.jsonl     tags the user follows
+//	followees.jsonl          users the user follows
+//	followers.jsonl          users following the user
+//	projects.jsonl           projects on Qiita:Team
+//	templates.jsonl          templates on Qiita:Team
+//	mapping.json             ids of the content migrated by an import, see Mapping
+//
+// An export saves a checkpoint after each page or item, so that it can be interrupted, e.g. by a
+// cancelled context or a failure, and continued later by exporting to the same directory.
+//
+// An Importer restores an archive into another account or team, e.g. to migrate from public Qiita to
+// Qiita:Team:
+//
+//	_, err := qiitaarchive.NewExporter(public, "backup").Export(ctx)
+//	report, err := qiitaarchive.NewImporter(team, "backup").Import(ctx)
+package qiitaarchive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Version of the archive format written in the manifest.
+const Version = 1
+
+// Files of an archive.
+const (
+	manifestFile   = "manifest.json"
+	checkpointFile = "checkpoint.json"
+	userFile       = "user.json"
+	itemsDir       = "items"
+)
+
+// Describes an archive.
+type Manifest struct {
+	Version     int          `json:"version"`
+	User        qiita.UserId `json:"user"`
+	StartedAt   time.Time    `json:"started_at"`
+	CompletedAt time.Time    `json:"completed_at,omitzero"`
+
+	// Number of records of each section, e.g. "items" for items.jsonl.
+	Counts map[string]int `json:"counts"`
+
+	// Sections not available from the exported account, e.g. "projects" on public Qiita.
+	Skipped []string `json:"skipped,omitempty"`
+
+	// Sections cut off at the last page the API serves, e.g. "followers" with more than 10,000 users.
+	Truncated []string `json:"truncated,omitempty"`
+}
+
+// A comment on an item.
+type CommentRecord struct {
+	ItemId  qiita.ItemId  `json:"item_id"`
+	Comment qiita.Comment `json:"comment"`
+}
+
+// An emoji reaction on an item or a comment.
+type ReactionRecord struct {
+	ItemId    qiita.ItemId    `json:"item_id,omitempty"`
+	CommentId qiita.CommentId `json:"comment_id,omitempty"`
+	Reaction  qiita.Reaction  `json:"reaction"`
+}
+
+// A like of an item.
+type LikeRecord struct {
+	ItemId qiita.ItemId `json:"item_id"`
+	Like   qiita.Like   `json:"like"`
+}
+
+// Progress of an export, per section.
+type checkpoint struct {
+	Sections map[string]*progress `json:"sections"`
+}
+
+type progress struct {
+	Done  bool `json:"done"`
+	Page  uint `json:"page,omitempty"`  // next page of a paginated section
+	Index int  `json:"index,omitempty"` // next item of a section exported per item
+
+	// Whether the section has more pages than the API serves.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Number of records written to each file of the section.
+	Counts map[string]int `json:"counts,omitempty"`
+
+	// Sizes of the files of the section at the checkpoint. Records written after it are discarded
+	// when the section is continued.
+	Offsets map[string]int64 `json:"offsets,omitempty"`
+}
+
+func readJSON(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(name), err)
+	}
+	return nil
+}
+
+// writeJSON replaces a file atomically, so that an interruption leaves either the old or the new contents.
+func writeJSON(name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// readLines decodes each line of a JSON Lines file of the archive.
+func readLines[T any](dir, file string, f func(T) error) error {
+	r, err := os.Open(filepath.Join(dir, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 64<<20)
+	for n := 1; sc.Scan(); n++ {
+		var v T
+		if err := json.Unmarshal(sc.Bytes(), &v); err != nil {
+			return fmt.Errorf("%s:%d: %w", file, n, err)
+		}
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,409 @@
+package qiitaarchive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitasync"
+)
+
+// Exports everything of the authenticated user to an archive directory.
+type Exporter struct {
+	pacer
+	dir     string
+	perPage uint
+	maxPage uint
+}
+
+func NewExporter(client qiita.API, dir string) *Exporter {
+	// Qiita API v2 rejects page numbers greater than 100.
+	return &Exporter{pacer: newPacer(client), dir: dir, perPage: 100, maxPage: 100}
+}
+
+// Leaves n requests of each rate limit window to other uses of the access token: once no more than n
+// requests remain, the export waits for the rate limit to reset. It requires a client reporting the
+// rate limit, such as *qiita.Client.
+func (e *Exporter) WithReserve(n uint) *Exporter {
+	e.reserve = n
+	return e
+}
+
+// State of an export in progress.
+type export struct {
+	*Exporter
+	manifest   *Manifest
+	checkpoint *checkpoint
+}
+
+// Exports the user, items, comments, reactions, likes, stocks, following tags, followees, followers,
+// projects and templates, and returns the manifest of the archive. An unfinished export in the
+// directory is continued, and a finished one is exported again. Lists longer than the API serves
+// are exported up to their last available page, and reported in Manifest.Truncated.
+func (e *Exporter) Export(ctx context.Context) (*Manifest, error) {
+	if err := os.MkdirAll(filepath.Join(e.dir, itemsDir), 0o755); err != nil {
+		return nil, err
+	}
+	x := &export{Exporter: e, manifest: &Manifest{}, checkpoint: &checkpoint{}}
+	err := readJSON(filepath.Join(e.dir, checkpointFile), x.checkpoint)
+	if errors.Is(err, fs.ErrNotExist) {
+		x.manifest, err = &Manifest{Version: Version, StartedAt: e.now()}, nil
+	} else if err == nil {
+		err = readJSON(filepath.Join(e.dir, manifestFile), x.manifest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if x.manifest.Version != Version {
+		return nil, fmt.Errorf("qiitaarchive: unsupported archive version %d", x.manifest.Version)
+	}
+	if x.checkpoint.Sections == nil {
+		x.checkpoint.Sections = map[string]*progress{}
+	}
+
+	var user *qiita.AuthenticatedUser
+	if err := x.call(ctx, func() (err error) {
+		user, err = e.client.GetAuthenticatedUser(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if x.manifest.User != "" && x.manifest.User != user.Id {
+		return nil, fmt.Errorf("qiitaarchive: %s has an unfinished export of %s, not %s", e.dir, x.manifest.User, user.Id)
+	}
+	x.manifest.User = user.Id
+	if err := writeJSON(filepath.Join(e.dir, userFile), user); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(filepath.Join(e.dir, manifestFile), x.manifest); err != nil {
+		return nil, err
+	}
+	if err := x.save(); err != nil {
+		return nil, err
+	}
+
+	for _, s := range x.sections() {
+		if err := x.run(ctx, s); err != nil {
+			return nil, fmt.Errorf("qiitaarchive: exporting %s: %w", s.name, err)
+		}
+	}
+
+	x.manifest.CompletedAt = e.now()
+	x.manifest.Counts = map[string]int{}
+	x.manifest.Truncated = nil
+	for _, s := range x.sections() {
+		p := x.checkpoint.Sections[s.name]
+		for file, n := range p.Counts {
+			x.manifest.Counts[file] = n
+		}
+		if p.Truncated {
+			x.manifest.Truncated = append(x.manifest.Truncated, s.name)
+		}
+	}
+	if err := writeJSON(filepath.Join(e.dir, manifestFile), x.manifest); err != nil {
+		return nil, err
+	}
+	return x.manifest, os.Remove(filepath.Join(e.dir, checkpointFile))
+}
+
+// A part of an export, writing to the given files.
+type section struct {
+	name   string
+	files  []string
+	export func(ctx context.Context, w *sectionWriter) error
+}
+
+func (x *export) sections() []section {
+	c, user := x.client, x.manifest.User
+	return []section{
+		{"items", []string{"items"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, c.ListAuthenticatedUserItems, func(item qiita.Item) error {
+				if err := w.write("items", item); err != nil {
+					return err
+				}
+				return x.writeBody(item)
+			})
+		}},
+		{"comments", []string{"comments", "comment_reactions"}, func(ctx context.Context, w *sectionWriter) error {
+			return perItem(ctx, x, w, func(itemId qiita.ItemId) error {
+				var comments *qiita.Comments
+				if err := x.call(ctx, func() (err error) {
+					comments, err = c.ListComments(ctx, itemId)
+					return err
+				}); err != nil {
+					return err
+				}
+				for _, comment := range *comments {
+					if err := w.write("comments", CommentRecord{itemId, comment}); err != nil {
+						return err
+					}
+					var reactions *qiita.Reactions
+					if err := x.call(ctx, func() (err error) {
+						reactions, err = c.ListCommentReactions(ctx, comment.Id)
+						return err
+					}); err != nil {
+						return err
+					}
+					for _, reaction := range *reactions {
+						if err := w.write("comment_reactions", ReactionRecord{CommentId: comment.Id, Reaction: reaction}); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+		}},
+		{"item_reactions", []string{"item_reactions"}, func(ctx context.Context, w *sectionWriter) error {
+			return perItem(ctx, x, w, func(itemId qiita.ItemId) error {
+				var reactions *qiita.Reactions
+				if err := x.call(ctx, func() (err error) {
+					reactions, err = c.ListItemReactions(ctx, itemId)
+					return err
+				}); err != nil {
+					return err
+				}
+				for _, reaction := range *reactions {
+					if err := w.write("item_reactions", ReactionRecord{ItemId: itemId, Reaction: reaction}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}},
+		{"likes", []string{"likes"}, func(ctx context.Context, w *sectionWriter) error {
+			return perItem(ctx, x, w, func(itemId qiita.ItemId) error {
+				var likes *qiita.Likes
+				if err := x.call(ctx, func() (err error) {
+					likes, err = c.ListItemLikes(ctx, itemId)
+					return err
+				}); err != nil {
+					return err
+				}
+				for _, like := range *likes {
+					if err := w.write("likes", LikeRecord{itemId, like}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}},
+		{"stocks", []string{"stocks"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, func(ctx context.Context, page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+				return c.ListUserStocks(ctx, user, page, perPage)
+			}, writer[qiita.Item](w, "stocks"))
+		}},
+		{"following_tags", []string{"following_tags"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, func(ctx context.Context, page, perPage uint) (*qiita.Tags, *qiita.Page, error) {
+				return c.ListFollowingTags(ctx, user, page, perPage)
+			}, writer[qiita.Tag](w, "following_tags"))
+		}},
+		{"followees", []string{"followees"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, func(ctx context.Context, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+				return c.ListFollowees(ctx, user, page, perPage)
+			}, writer[qiita.User](w, "followees"))
+		}},
+		{"followers", []string{"followers"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, func(ctx context.Context, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+				return c.ListFollowers(ctx, user, page, perPage)
+			}, writer[qiita.User](w, "followers"))
+		}},
+		{"projects", []string{"projects"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, c.ListProjects, writer[qiita.Project](w, "projects"))
+		}},
+		{"templates", []string{"templates"}, func(ctx context.Context, w *sectionWriter) error {
+			return pages(ctx, x, w, c.ListTemplates, writer[qiita.Template](w, "templates"))
+		}},
+	}
+}
+
+// run exports a section unless it is done. A section only available on Qiita:Team is skipped on public Qiita.
+func (x *export) run(ctx context.Context, s section) error {
+	p := x.checkpoint.Sections[s.name]
+	if p == nil {
+		p = &progress{}
+		x.checkpoint.Sections[s.name] = p
+	}
+	if p.Done {
+		return nil
+	}
+	w, err := x.open(p, s.files)
+	if err != nil {
+		return err
+	}
+	defer w.close()
+	err = s.export(ctx, w)
+	if errors.Is(err, qiita.ErrTeamOnly) {
+		p.Done = true
+		if !slices.Contains(x.manifest.Skipped, s.name) {
+			x.manifest.Skipped = append(x.manifest.Skipped, s.name)
+		}
+		return w.checkpoint()
+	}
+	return err
+}
+
+// pages exports each result of a paginated list, saving a checkpoint after each page. The list is
+// cut off after the last page the API serves.
+func pages[S ~[]E, E any](ctx context.Context, x *export, w *sectionWriter, list func(ctx context.Context, page, perPage uint) (*S, *qiita.Page, error), each func(E) error) error {
+	for {
+		page := max(w.p.Page, 1)
+		var results *S
+		var next *qiita.Page
+		if err := x.call(ctx, func() (err error) {
+			results, next, err = list(ctx, page, x.perPage)
+			return err
+		}); err != nil {
+			return err
+		}
+		for _, e := range *results {
+			if err := each(e); err != nil {
+				return err
+			}
+		}
+		switch n := nextPage(page, x.perPage, next, len(*results)); {
+		case n == 0:
+			w.p.Done = true
+		case n > x.maxPage:
+			w.p.Done, w.p.Truncated = true, true
+		default:
+			w.p.Page = n
+		}
+		if err := w.checkpoint(); err != nil {
+			return err
+		}
+		if w.p.Done {
+			return nil
+		}
+	}
+}
+
+// nextPage returns the page following page of a list, given the pagination of the response and its
+// number of results, or 0 after the last page. Like the iterators of qiita, it keeps going while pages
+// are full when the response has no Link header, e.g. one removed by a proxy.
+func nextPage(page, perPage uint, next *qiita.Page, n int) uint {
+	switch {
+	case next != nil && next.Next > page:
+		return next.Next
+	case (next == nil || next.Last == 0) && n > 0 && uint(n) == perPage:
+		return page + 1
+	}
+	return 0
+}
+
+// perItem exports something of each exported item, saving a checkpoint after each item.
+func perItem(ctx context.Context, x *export, w *sectionWriter, each func(itemId qiita.ItemId) error) error {
+	var ids []qiita.ItemId
+	if err := readLines(x.dir, "items.jsonl", func(item qiita.Item) error {
+		ids = append(ids, item.Id)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for w.p.Index < len(ids) {
+		if err := each(ids[w.p.Index]); err != nil {
+			return err
+		}
+		w.p.Index++
+		if err := w.checkpoint(); err != nil {
+			return err
+		}
+	}
+	w.p.Done = true
+	return w.checkpoint()
+}
+
+// writeBody saves the body of an item as a Markdown file with front matter.
+func (x *export) writeBody(item qiita.Item) error {
+	id := string(item.Id)
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("invalid item id %q", id)
+	}
+	return os.WriteFile(filepath.Join(x.dir, itemsDir, id+".md"), qiitasync.NewDocument(item).Bytes(), 0o644)
+}
+
+func (x *export) save() error {
+	return writeJSON(filepath.Join(x.dir, checkpointFile), x.checkpoint)
+}
+
+// Appends records to the JSON Lines files of a section.
+type sectionWriter struct {
+	x     *export
+	p     *progress
+	files map[string]*os.File
+}
+
+// open opens the files of a section, discarding the records written after its last checkpoint.
+func (x *export) open(p *progress, files []string) (*sectionWriter, error) {
+	w := &sectionWriter{x: x, p: p, files: map[string]*os.File{}}
+	if p.Offsets == nil {
+		p.Offsets = map[string]int64{}
+	}
+	if p.Counts == nil {
+		p.Counts = map[string]int{}
+	}
+	for _, file := range files {
+		f, err := os.OpenFile(filepath.Join(x.dir, file+".jsonl"), os.O_WRONLY|os.O_CREATE, 0o644)
+		if err != nil {
+			w.close()
+			return nil, err
+		}
+		w.files[file] = f
+		if _, ok := p.Counts[file]; !ok {
+			p.Counts[file] = 0
+		}
+		if err := f.Truncate(p.Offsets[file]); err != nil {
+			w.close()
+			return nil, err
+		}
+		if _, err := f.Seek(p.Offsets[file], 0); err != nil {
+			w.close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (w *sectionWriter) write(file string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := w.files[file].Write(append(b, '\n')); err != nil {
+		return err
+	}
+	w.p.Counts[file]++
+	return nil
+}
+
+// writer returns a function writing each record to a file of a section.
+func writer[E any](w *sectionWriter, file string) func(E) error {
+	return func(e E) error { return w.write(file, e) }
+}
+
+// checkpoint records the sizes of the files with the progress of the section.
+func (w *sectionWriter) checkpoint() error {
+	for file, f := range w.files {
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		offset, err := f.Seek(0, 1)
+		if err != nil {
+			return err
+		}
+		w.p.Offsets[file] = offset
+	}
+	return w.x.save()
+}
+
+func (w *sectionWriter) close() {
+	for _, f := range w.files {
+		f.Close()
+	}
+}
@@ -0,0 +1,315 @@
+package qiitaarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/internal/yamlscalar"
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitatest"
+)
+
+// A clock of the server and the exporter, which sleeping advances.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return nil
+}
+
+// newAccount returns a server with an account of alice having items, comments, reactions, stocks,
+// follows, projects and templates, and a client of alice.
+func newAccount(t *testing.T, c *clock) (*qiitatest.Server, *qiita.Client) {
+	t.Helper()
+	ctx := context.Background()
+	server := qiitatest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetClock(c.Now)
+	alice := server.Client(server.AddUser(qiita.User{Id: "alice"}))
+	bob := server.Client(server.AddUser(qiita.User{Id: "bob"}))
+
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range 5 {
+		item, err := alice.CreateItem(ctx, qiita.Item{
+			Title: fmt.Sprintf("Item %d", i),
+			Body:  fmt.Sprintf("# Item %d\n", i),
+			Tags:  qiita.Taggings{{Name: "Go", Versions: []string{"1.22"}}},
+		})
+		check(err)
+		comment, err := bob.PostComment(ctx, item.Id, qiita.Comment{Body: "Nice"})
+		check(err)
+		_, err = alice.AddCommentReaction(ctx, comment.Id, qiita.Reaction{Name: "+1"})
+		check(err)
+		_, err = bob.AddItemReaction(ctx, item.Id, qiita.Reaction{Name: "tada"})
+		check(err)
+		check(bob.LikeItem(ctx, item.Id))
+		check(alice.StockItem(ctx, item.Id))
+	}
+	check(alice.FollowTag(ctx, "Go"))
+	check(alice.FollowUser(ctx, "bob"))
+	check(bob.FollowUser(ctx, "alice"))
+	_, err := alice.CreateProject(ctx, qiita.Project{Name: "Archive", Body: "Plans"})
+	check(err)
+	_, err = alice.CreateTemplate(ctx, qiita.Template{Name: "Daily", Title: "Daily %{Year}", Body: "Done:"})
+	check(err)
+	return server, alice
+}
+
+func newExporter(client qiita.API, dir string, c *clock) *Exporter {
+	e := NewExporter(client, dir)
+	e.perPage, e.now, e.sleep = 2, c.Now, c.Sleep
+	return e
+}
+
+var wantCounts = map[string]int{
+	"items":             5,
+	"comments":          5,
+	"comment_reactions": 5,
+	"item_reactions":    5,
+	"likes":             5,
+	"stocks":            5,
+	"following_tags":    1,
+	"followees":         1,
+	"followers":         1,
+	"projects":          1,
+	"templates":         1,
+}
+
+func lines(t *testing.T, dir, file string) []string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+}
+
+func checkArchive(t *testing.T, dir string, m *Manifest) {
+	t.Helper()
+	if m.User != "alice" || m.CompletedAt.IsZero() || len(m.Skipped) != 0 || len(m.Truncated) != 0 {
+		t.Errorf("manifest = %+v", m)
+	}
+	for file, n := range wantCounts {
+		if m.Counts[file] != n {
+			t.Errorf("count of %s = %d, want %d", file, m.Counts[file], n)
+		}
+		seen := map[string]bool{}
+		records := lines(t, dir, file+".jsonl")
+		for _, line := range records {
+			if seen[line] {
+				t.Errorf("%s has a duplicate record %s", file, line)
+			}
+			seen[line] = true
+		}
+		if len(records) != n {
+			t.Errorf("%s has %d records, want %d", file, len(records), n)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, checkpointFile)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint is left: %v", err)
+	}
+	var got Manifest
+	if err := readJSON(filepath.Join(dir, manifestFile), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.User != m.User || !got.CompletedAt.Equal(m.CompletedAt) {
+		t.Errorf("manifest.json = %+v, want %+v", got, m)
+	}
+	var ids []qiita.ItemId
+	if err := readLines(dir, "items.jsonl", func(item qiita.Item) error {
+		ids = append(ids, item.Id)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		b, err := os.ReadFile(filepath.Join(dir, itemsDir, string(id)+".md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), "id: "+yamlscalar.String(string(id))+"\n") {
+			t.Errorf("items/%s.md has no id:\n%s", id, b)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, client := newAccount(t, c)
+	// Exhaust the rate limit a few times on the way.
+	server.SetRateLimit(10, 10)
+	dir := t.TempDir()
+	start := c.Now()
+
+	m, err := newExporter(client, dir, c).Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkArchive(t, dir, m)
+	if !c.Now().After(start.Add(time.Hour)) {
+		t.Errorf("export did not wait for the rate limit to reset")
+	}
+}
+
+func TestExportTruncated(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	_, client := newAccount(t, c)
+	dir := t.TempDir()
+	e := newExporter(client, dir, c)
+	e.maxPage = 2
+
+	m, err := e.Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"items", "stocks"}; !slices.Equal(m.Truncated, want) {
+		t.Errorf("truncated = %v, want %v", m.Truncated, want)
+	}
+	for _, file := range []string{"items", "stocks", "comments"} {
+		if m.Counts[file] != 4 {
+			t.Errorf("count of %s = %d, want 4", file, m.Counts[file])
+		}
+	}
+	var got Manifest
+	if err := readJSON(filepath.Join(dir, manifestFile), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Truncated, m.Truncated) {
+		t.Errorf("manifest.json truncated = %v, want %v", got.Truncated, m.Truncated)
+	}
+}
+
+// Lists are exported in full from a server whose responses have no Link header.
+func TestExportWithoutLinks(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, client := newAccount(t, c)
+	target, _ := url.Parse(server.URL)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = func(res *http.Response) error {
+		res.Header.Del("Link")
+		return nil
+	}
+	stripped := httptest.NewServer(proxy)
+	defer stripped.Close()
+	client, err := qiita.NewClient(client.Token, *qiita.NewConfig().WithEndpoint(stripped.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	m, err := newExporter(client, dir, c).Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkArchive(t, dir, m)
+
+	// Full pages are followed up to the last page the API serves.
+	e := newExporter(client, t.TempDir(), c)
+	e.maxPage = 2
+	m, err = e.Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"items", "stocks"}; !slices.Equal(m.Truncated, want) {
+		t.Errorf("truncated = %v, want %v", m.Truncated, want)
+	}
+}
+
+func TestExportReserve(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, client := newAccount(t, c)
+	server.SetRateLimit(1000, 60)
+	var waits int
+	e := newExporter(client, t.TempDir(), c).WithReserve(995)
+	e.sleep = func(ctx context.Context, d time.Duration) error {
+		waits++
+		return c.Sleep(ctx, d)
+	}
+	if _, err := e.Export(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if waits == 0 {
+		t.Error("export did not leave the reserve")
+	}
+}
+
+func TestExportResume(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, client := newAccount(t, c)
+	dir := t.TempDir()
+	interrupted := errors.New("interrupted")
+
+	// Interrupt the export at each rate limit reset in turn, then let it finish.
+	for calls := 1; ; calls++ {
+		server.SetRateLimit(7, 7)
+		e := newExporter(client, dir, c)
+		n := 0
+		e.sleep = func(ctx context.Context, d time.Duration) error {
+			if n++; n == calls {
+				c.Sleep(ctx, d)
+				return interrupted
+			}
+			return c.Sleep(ctx, d)
+		}
+		m, err := e.Export(context.Background())
+		if err == nil {
+			if calls == 1 {
+				t.Fatal("export was never interrupted")
+			}
+			checkArchive(t, dir, m)
+			return
+		}
+		if !errors.Is(err, interrupted) {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, checkpointFile)); err != nil {
+			t.Fatalf("no checkpoint after interruption %d: %v", calls, err)
+		}
+	}
+}
+
+func TestExportOtherUser(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, client := newAccount(t, c)
+	dir := t.TempDir()
+	server.SetRateLimit(3, 3)
+	e := newExporter(client, dir, c)
+	e.sleep = func(ctx context.Context, d time.Duration) error { return context.Canceled }
+	if _, err := e.Export(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Export = %v, want %v", err, context.Canceled)
+	}
+
+	carol := server.Client(server.AddUser(qiita.User{Id: "carol"}))
+	_, err := newExporter(carol, dir, c).Export(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unfinished export of alice") {
+		t.Errorf("Export = %v, want an error of the unfinished export", err)
+	}
+}
@@ -0,0 +1,409 @@
+package qiitaarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// File of the mapping of an import in the archive directory, unless another file is given.
+const mappingFile = "mapping.json"
+
+// Ids of the content migrated by an import, by their ids in the archive. An import skips the content
+// it has already migrated, so that it can be run again after a failure.
+type Mapping struct {
+	Items     map[qiita.ItemId]*MappedItem          `json:"items"`
+	Comments  map[qiita.CommentId]qiita.CommentId   `json:"comments"`
+	Projects  map[qiita.ProjectId]qiita.ProjectId   `json:"projects"`
+	Templates map[qiita.TemplateId]qiita.TemplateId `json:"templates"`
+}
+
+// A migrated item.
+type MappedItem struct {
+	Id  qiita.ItemId `json:"id"`
+	Url string       `json:"url"`
+
+	// Whether the links of the body to other items of the archive point to their migrated items.
+	Linked bool `json:"linked"`
+}
+
+// Content of an archive which an import cannot migrate.
+type Unmigrated struct {
+	Section string `json:"section"` // e.g. "comments"
+	Id      string `json:"id"`
+	Reason  string `json:"reason"`
+}
+
+// Result of an import.
+type Report struct {
+	Created    map[string]int `json:"created"`  // number of records of each section migrated by the import
+	Existing   map[string]int `json:"existing"` // number of records of each section migrated before
+	Unmigrated []Unmigrated   `json:"unmigrated,omitempty"`
+}
+
+// Restores an archive into another account or team.
+type Importer struct {
+	pacer
+	dir         string
+	mappingName string
+}
+
+func NewImporter(client qiita.API, dir string) *Importer {
+	return &Importer{pacer: newPacer(client), dir: dir, mappingName: filepath.Join(dir, mappingFile)}
+}
+
+// Records the mapping in a file other than mapping.json in the archive, e.g. to import an archive into
+// several accounts.
+func (i *Importer) WithMapping(name string) *Importer {
+	i.mappingName = name
+	return i
+}
+
+// Leaves n requests of each rate limit window to other uses of the access token, as Exporter.WithReserve.
+func (i *Importer) WithReserve(n uint) *Importer {
+	i.reserve = n
+	return i
+}
+
+// State of an import in progress.
+type restore struct {
+	*Importer
+	manifest *Manifest
+	mapping  *Mapping
+	report   *Report
+	errs     []error
+
+	// Items of the archive, oldest first.
+	items []qiita.Item
+
+	// Url names of the groups of the target team.
+	groups map[string]bool
+}
+
+// Posts the items of the archive, then the comments the archived user posted on them, the projects and
+// the templates, rewriting links to the items of the archive into links to the migrated items.
+//
+// Comments of other users, reactions and likes cannot be posted on their behalf, and are reported as
+// unmigrated. So are projects and templates when the client targets public Qiita, and items in a group
+// which the target has no group of the same url name for, rather than being posted outside of a group. Stocks and follows
+// are not migrated. A failure to post something does not stop the import: it goes on, and returns the
+// errors of all the failures with the report.
+func (i *Importer) Import(ctx context.Context) (*Report, error) {
+	r := &restore{
+		Importer: i,
+		manifest: &Manifest{},
+		mapping:  &Mapping{},
+		report:   &Report{Created: map[string]int{}, Existing: map[string]int{}},
+	}
+	for _, section := range []string{"items", "comments", "projects", "templates"} {
+		r.report.Created[section], r.report.Existing[section] = 0, 0
+	}
+	if err := readJSON(filepath.Join(i.dir, manifestFile), r.manifest); err != nil {
+		return nil, err
+	}
+	if r.manifest.Version != Version {
+		return nil, fmt.Errorf("qiitaarchive: unsupported archive version %d", r.manifest.Version)
+	}
+	if r.manifest.CompletedAt.IsZero() {
+		return nil, fmt.Errorf("qiitaarchive: %s is an unfinished export", i.dir)
+	}
+	if err := readJSON(i.mappingName, r.mapping); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if r.mapping.Items == nil {
+		r.mapping.Items = map[qiita.ItemId]*MappedItem{}
+	}
+	if r.mapping.Comments == nil {
+		r.mapping.Comments = map[qiita.CommentId]qiita.CommentId{}
+	}
+	if r.mapping.Projects == nil {
+		r.mapping.Projects = map[qiita.ProjectId]qiita.ProjectId{}
+	}
+	if r.mapping.Templates == nil {
+		r.mapping.Templates = map[qiita.TemplateId]qiita.TemplateId{}
+	}
+	if err := readLines(i.dir, "items.jsonl", func(item qiita.Item) error {
+		r.items = append(r.items, item)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// The archive lists items newest first. Posting the oldest first lets most links to earlier items be
+	// rewritten as the items are posted.
+	slices.Reverse(r.items)
+
+	for _, step := range []func(ctx context.Context) error{
+		r.loadGroups, r.importItems, r.linkItems, r.importComments, r.importProjects, r.importTemplates, r.reportReactions,
+	} {
+		if err := step(ctx); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return r.report, errors.Join(r.errs...)
+}
+
+// fail records the failure to migrate a record, or returns err if the import cannot go on.
+func (r *restore) fail(ctx context.Context, section, id string, err error) error {
+	if ctx.Err() != nil || errors.Is(err, qiita.ErrRateLimited) {
+		return err
+	}
+	r.errs = append(r.errs, fmt.Errorf("%s %s: %w", section, id, err))
+	return nil
+}
+
+func (r *restore) unmigrated(section, id, reason string) {
+	r.report.Unmigrated = append(r.report.Unmigrated, Unmigrated{section, id, reason})
+}
+
+func (r *restore) save() error {
+	return writeJSON(r.mappingName, r.mapping)
+}
+
+// itemUrl matches the URLs of items on Qiita and Qiita:Team, e.g. https://qiita.com/alice/items/<id>.
+var itemUrl = regexp.MustCompile(`https?://[\w.-]+(?::\d+)?(?:/[\w-]+)?/items/([0-9a-f]{20})\b`)
+
+// rewrite replaces the URLs of the items of the archive in s with the URLs of their migrated items,
+// and reports whether all of them have been migrated.
+func (r *restore) rewrite(s string) (string, bool) {
+	linked := true
+	s = itemUrl.ReplaceAllStringFunc(s, func(u string) string {
+		id := qiita.ItemId(itemUrl.FindStringSubmatch(u)[1])
+		if m := r.mapping.Items[id]; m != nil {
+			return m.Url
+		}
+		if slices.ContainsFunc(r.items, func(item qiita.Item) bool { return item.Id == id }) {
+			linked = false
+		}
+		return u
+	})
+	return s, linked
+}
+
+// loadGroups reads the groups of the target team, if items of the archive are in groups. Public Qiita
+// has none.
+func (r *restore) loadGroups(ctx context.Context) error {
+	r.groups = map[string]bool{}
+	if !slices.ContainsFunc(r.items, func(item qiita.Item) bool { return item.Group != nil }) {
+		return nil
+	}
+	const perPage = 100
+	for page := uint(1); page > 0; {
+		var groups *qiita.Groups
+		var next *qiita.Page
+		err := r.call(ctx, func() (err error) {
+			groups, next, err = r.client.ListGroups(ctx, page, perPage)
+			return err
+		})
+		if errors.Is(err, qiita.ErrTeamOnly) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, g := range *groups {
+			r.groups[g.UrlName] = true
+		}
+		page = nextPage(page, perPage, next, len(*groups))
+	}
+	return nil
+}
+
+// post returns the item to post for an item of the archive.
+func (r *restore) post(item qiita.Item) (qiita.Item, bool) {
+	body, linked := r.rewrite(item.Body)
+	post := qiita.Item{
+		Body:      body,
+		Coediting: item.Coediting,
+		Private:   item.Private,
+		Slide:     item.Slide,
+		Tags:      item.Tags,
+		Title:     item.Title,
+	}
+	if item.Group != nil {
+		post.GroupUrlName = item.Group.UrlName
+	}
+	return post, linked
+}
+
+func (r *restore) importItems(ctx context.Context) error {
+	for _, item := range r.items {
+		if r.mapping.Items[item.Id] != nil {
+			r.report.Existing["items"]++
+			continue
+		}
+		if item.Group != nil && !r.groups[item.Group.UrlName] {
+			r.unmigrated("items", string(item.Id), "group "+item.Group.UrlName+" does not exist in the target")
+			continue
+		}
+		post, linked := r.post(item)
+		var created *qiita.Item
+		err := r.call(ctx, func() (err error) {
+			created, err = r.client.CreateItem(ctx, post)
+			return err
+		})
+		if err != nil {
+			if err := r.fail(ctx, "items", string(item.Id), err); err != nil {
+				return err
+			}
+			continue
+		}
+		r.mapping.Items[item.Id] = &MappedItem{Id: created.Id, Url: created.Url, Linked: linked}
+		r.report.Created["items"]++
+		if err := r.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkItems updates the migrated items linking to items which were migrated after them.
+func (r *restore) linkItems(ctx context.Context) error {
+	for _, item := range r.items {
+		m := r.mapping.Items[item.Id]
+		if m == nil || m.Linked {
+			continue
+		}
+		post, linked := r.post(item)
+		if !linked {
+			// Some linked items failed to migrate: retry on the next import.
+			continue
+		}
+		post.Id = m.Id
+		err := r.call(ctx, func() (err error) {
+			_, err = r.client.UpdateItem(ctx, post)
+			return err
+		})
+		if err != nil {
+			if err := r.fail(ctx, "items", string(item.Id), err); err != nil {
+				return err
+			}
+			continue
+		}
+		m.Linked = true
+		if err := r.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restore) importComments(ctx context.Context) error {
+	return readLines(r.dir, "comments.jsonl", func(c CommentRecord) error {
+		id := string(c.Comment.Id)
+		switch item := r.mapping.Items[c.ItemId]; {
+		case r.mapping.Comments[c.Comment.Id] != "":
+			r.report.Existing["comments"]++
+		case c.Comment.User.Id != r.manifest.User:
+			r.unmigrated("comments", id, "posted by "+string(c.Comment.User.Id))
+		case item == nil:
+			r.unmigrated("comments", id, "item "+string(c.ItemId)+" is not migrated")
+		default:
+			body, _ := r.rewrite(c.Comment.Body)
+			var created *qiita.Comment
+			err := r.call(ctx, func() (err error) {
+				created, err = r.client.PostComment(ctx, item.Id, qiita.Comment{Body: body})
+				return err
+			})
+			if err != nil {
+				return r.fail(ctx, "comments", id, err)
+			}
+			r.mapping.Comments[c.Comment.Id] = created.Id
+			r.report.Created["comments"]++
+			return r.save()
+		}
+		return nil
+	})
+}
+
+func (r *restore) importProjects(ctx context.Context) error {
+	teamOnly := false
+	return readLines(r.dir, "projects.jsonl", func(p qiita.Project) error {
+		id := fmt.Sprint(p.Id)
+		if _, ok := r.mapping.Projects[p.Id]; ok {
+			r.report.Existing["projects"]++
+			return nil
+		}
+		if teamOnly {
+			r.unmigrated("projects", id, qiita.ErrTeamOnly.Error())
+			return nil
+		}
+		body, _ := r.rewrite(p.Body)
+		var created *qiita.Project
+		err := r.call(ctx, func() (err error) {
+			created, err = r.client.CreateProject(ctx, qiita.Project{Archived: p.Archived, Body: body, Name: p.Name, Tags: p.Tags})
+			return err
+		})
+		if errors.Is(err, qiita.ErrTeamOnly) {
+			teamOnly = true
+			r.unmigrated("projects", id, err.Error())
+			return nil
+		}
+		if err != nil {
+			return r.fail(ctx, "projects", id, err)
+		}
+		r.mapping.Projects[p.Id] = created.Id
+		r.report.Created["projects"]++
+		return r.save()
+	})
+}
+
+func (r *restore) importTemplates(ctx context.Context) error {
+	teamOnly := false
+	return readLines(r.dir, "templates.jsonl", func(t qiita.Template) error {
+		id := fmt.Sprint(t.Id)
+		if _, ok := r.mapping.Templates[t.Id]; ok {
+			r.report.Existing["templates"]++
+			return nil
+		}
+		if teamOnly {
+			r.unmigrated("templates", id, qiita.ErrTeamOnly.Error())
+			return nil
+		}
+		var created *qiita.Template
+		err := r.call(ctx, func() (err error) {
+			created, err = r.client.CreateTemplate(ctx, qiita.Template{Body: t.Body, Name: t.Name, Tags: t.Tags, Title: t.Title})
+			return err
+		})
+		if errors.Is(err, qiita.ErrTeamOnly) {
+			teamOnly = true
+			r.unmigrated("templates", id, err.Error())
+			return nil
+		}
+		if err != nil {
+			return r.fail(ctx, "templates", id, err)
+		}
+		r.mapping.Templates[t.Id] = created.Id
+		r.report.Created["templates"]++
+		return r.save()
+	})
+}
+
+// reportReactions reports the reactions and likes, which belong to the users who gave them.
+func (r *restore) reportReactions(context.Context) error {
+	const reason = "given by " // followed by the user
+	if err := readLines(r.dir, "comment_reactions.jsonl", func(re ReactionRecord) error {
+		r.unmigrated("comment_reactions", string(re.CommentId)+" "+re.Reaction.Name, reason+string(re.Reaction.User.Id))
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := readLines(r.dir, "item_reactions.jsonl", func(re ReactionRecord) error {
+		r.unmigrated("item_reactions", string(re.ItemId)+" "+re.Reaction.Name, reason+string(re.Reaction.User.Id))
+		return nil
+	}); err != nil {
+		return err
+	}
+	return readLines(r.dir, "likes.jsonl", func(l LikeRecord) error {
+		r.unmigrated("likes", string(l.ItemId), reason+string(l.Like.User.Id))
+		return nil
+	})
+}
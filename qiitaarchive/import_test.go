@@ -0,0 +1,180 @@
+package qiitaarchive
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitatest"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	source, alice := newAccount(t, c)
+	items, _, err := alice.ListAuthenticatedUserItems(ctx, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Link the oldest and the newest items to each other, and comment on the newest.
+	oldest, newest := (*items)[len(*items)-1], (*items)[0]
+	oldest.Body += "See " + newest.Url + "#next.\n"
+	if _, err := alice.UpdateItem(ctx, oldest); err != nil {
+		t.Fatal(err)
+	}
+	newest.Body += "Follows [the first](" + oldest.Url + ").\n"
+	if _, err := alice.UpdateItem(ctx, newest); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.PostComment(ctx, newest.Id, qiita.Comment{Body: "Also " + oldest.Url}); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if _, err := newExporter(alice, dir, c).Export(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	target := qiitatest.NewServer()
+	defer target.Close()
+	dave := target.Client(target.AddUser(qiita.User{Id: "dave"}))
+	report, err := NewImporter(dave, dir).Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"items": 5, "comments": 1, "projects": 1, "templates": 1}
+	for section, n := range want {
+		if report.Created[section] != n || report.Existing[section] != 0 {
+			t.Errorf("%s: created %d, existing %d, want %d created", section, report.Created[section], report.Existing[section], n)
+		}
+	}
+	unmigrated := map[string]int{}
+	for _, u := range report.Unmigrated {
+		unmigrated[u.Section]++
+		if u.Section == "comments" && u.Reason != "posted by bob" {
+			t.Errorf("unmigrated comment %s: %s", u.Id, u.Reason)
+		}
+	}
+	if unmigrated["comments"] != 5 || unmigrated["comment_reactions"] != 5 || unmigrated["item_reactions"] != 5 || unmigrated["likes"] != 5 {
+		t.Errorf("unmigrated = %v", unmigrated)
+	}
+
+	migrated, _, err := dave.ListAuthenticatedUserItems(ctx, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := map[string]qiita.Item{}
+	for _, item := range *migrated {
+		byTitle[item.Title] = item
+		if strings.Contains(item.Body, source.URL) {
+			t.Errorf("%s links to the archived items: %q", item.Title, item.Body)
+		}
+	}
+	first, last := byTitle[oldest.Title], byTitle[newest.Title]
+	if want := "See " + last.Url + "#next.\n"; !strings.HasSuffix(first.Body, want) {
+		t.Errorf("first body = %q, want suffix %q", first.Body, want)
+	}
+	if want := "Follows [the first](" + first.Url + ").\n"; !strings.HasSuffix(last.Body, want) {
+		t.Errorf("last body = %q, want suffix %q", last.Body, want)
+	}
+	comments, err := dave.ListComments(ctx, last.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*comments) != 1 || (*comments)[0].Body != "Also "+first.Url {
+		t.Errorf("comments = %+v", *comments)
+	}
+
+	// Importing again migrates nothing more.
+	report, err = NewImporter(dave, dir).Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for section, n := range want {
+		if report.Created[section] != 0 || report.Existing[section] != n {
+			t.Errorf("again %s: created %d, existing %d, want %d existing", section, report.Created[section], report.Existing[section], n)
+		}
+	}
+	if again, _, _ := dave.ListAuthenticatedUserItems(ctx, 1, 100); len(*again) != 5 {
+		t.Errorf("%d items after importing again", len(*again))
+	}
+}
+
+func TestImportGroups(t *testing.T) {
+	ctx := context.Background()
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	source, alice := newAccount(t, c)
+	source.AddGroup(qiita.Group{Name: "Developers", UrlName: "dev"})
+	source.AddGroup(qiita.Group{Name: "Designers", UrlName: "design"})
+	for _, group := range []string{"dev", "design"} {
+		item := qiita.Item{Title: "For " + group, Body: "Internal", Tags: qiita.Taggings{{Name: "Go"}}, GroupUrlName: group}
+		if _, err := alice.CreateItem(ctx, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir := t.TempDir()
+	if _, err := newExporter(alice, dir, c).Export(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// An item is posted to the group of the same url name, and not posted without a group.
+	target := qiitatest.NewServer()
+	defer target.Close()
+	target.AddGroup(qiita.Group{Name: "Dev", UrlName: "dev"})
+	dave := target.Client(target.AddUser(qiita.User{Id: "dave"}))
+	report, err := NewImporter(dave, dir).Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Created["items"] != 6 {
+		t.Errorf("created %d items, want 6", report.Created["items"])
+	}
+	var unmigrated []string
+	for _, u := range report.Unmigrated {
+		if u.Section == "items" {
+			unmigrated = append(unmigrated, u.Reason)
+		}
+	}
+	if len(unmigrated) != 1 || unmigrated[0] != "group design does not exist in the target" {
+		t.Errorf("unmigrated items = %q", unmigrated)
+	}
+	migrated, _, err := dave.ListAuthenticatedUserItems(ctx, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := map[string]string{}
+	for _, item := range *migrated {
+		if item.Group != nil {
+			groups[item.Title] = item.Group.UrlName
+		}
+	}
+	if len(groups) != 1 || groups["For dev"] != "dev" {
+		t.Errorf("groups of the migrated items = %v", groups)
+	}
+
+	// The item is migrated once the group exists.
+	target.AddGroup(qiita.Group{Name: "Design", UrlName: "design"})
+	report, err = NewImporter(dave, dir).Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Created["items"] != 1 || report.Existing["items"] != 6 {
+		t.Errorf("again: created %d items, existing %d", report.Created["items"], report.Existing["items"])
+	}
+}
+
+func TestImportUnfinished(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	server, alice := newAccount(t, c)
+	dir := t.TempDir()
+	server.SetRateLimit(3, 3)
+	e := newExporter(alice, dir, c)
+	e.sleep = func(ctx context.Context, d time.Duration) error { return context.Canceled }
+	e.Export(context.Background())
+
+	_, err := NewImporter(alice, dir).Import(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unfinished export") {
+		t.Errorf("Import = %v, want an error of the unfinished export", err)
+	}
+}
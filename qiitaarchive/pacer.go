@@ -0,0 +1,77 @@
+package qiitaarchive
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Wait before retrying a request failed by the rate limit, when the client does not know when it resets.
+const rateLimitWait = time.Minute
+
+// Attempts of a request failed by the rate limit.
+const maxRateLimitedAttempts = 3
+
+// Paces the requests of a client by its rate limit.
+type pacer struct {
+	client  qiita.API
+	reserve uint
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newPacer(client qiita.API) pacer {
+	return pacer{client: client, now: time.Now, sleep: sleep}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// call calls f, pacing it by the rate limit and retrying it when it fails by the rate limit.
+func (p *pacer) call(ctx context.Context, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		if rl, ok := p.rateLimit(); ok && rl.Limit > 0 && rl.Remaining <= p.reserve {
+			if err := p.sleepUntil(ctx, rl.Reset); err != nil {
+				return err
+			}
+		}
+		err := f()
+		if !errors.Is(err, qiita.ErrRateLimited) || attempt == maxRateLimitedAttempts {
+			return err
+		}
+		reset := p.now().Add(rateLimitWait)
+		if rl, ok := p.rateLimit(); ok && rl.Reset.After(p.now()) {
+			reset = rl.Reset
+		}
+		if err := p.sleepUntil(ctx, reset); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *pacer) rateLimit() (qiita.RateLimit, bool) {
+	c, ok := p.client.(interface{ RateLimit() qiita.RateLimit })
+	if !ok {
+		return qiita.RateLimit{}, false
+	}
+	return c.RateLimit(), true
+}
+
+func (p *pacer) sleepUntil(ctx context.Context, t time.Time) error {
+	d := t.Sub(p.now())
+	if d <= 0 {
+		return ctx.Err()
+	}
+	return p.sleep(ctx, d)
+}
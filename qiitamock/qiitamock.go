@@ -0,0 +1,163 @@
+// Package qiitamock provides an in-memory fake of qiita.API for unit tests of code using the qiita package.
+//
+// The fake records every call and returns responses scripted per method:
+//
+//	m := qiitamock.New()
+//	m.Return("GetItem", &qiita.Item{Id: "c686397e4a0f4f11683d", Title: "Example"}, nil)
+//	m.Return("StockItem", nil, qiita.ErrNotFound)
+//
+//	var api qiita.API = m
+//	item, err := api.GetItem(ctx, "c686397e4a0f4f11683d")
+//
+//	calls := m.CallsTo("GetItem") // [{GetItem [c686397e4a0f4f11683d]}]
+package qiitamock
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Returned from a method which has no scripted response.
+var ErrNotScripted = errors.New("qiitamock: no response scripted")
+
+// A call made to the fake, with its arguments other than the context.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// A scripted response of a method.
+//
+// Value is the first result of the method, such as a *qiita.Item for GetItem. A non-pointer
+// qiita.Item is accepted as well. Methods iterating over pages, such as AllItems, yield the
+// elements of a slice such as qiita.Items or []qiita.Item, and then Err if it is set.
+// Page is only returned from methods listing a single page, such as ListItems.
+type Response struct {
+	Value any
+	Page  *qiita.Page
+	Err   error
+}
+
+// An in-memory fake of qiita.API. It is safe for concurrent use.
+type Client struct {
+	mu        sync.Mutex
+	calls     []Call
+	responses map[string][]Response
+}
+
+var _ qiita.API = (*Client)(nil)
+
+func New() *Client {
+	return &Client{responses: map[string][]Response{}}
+}
+
+// Scripts method to return value and err.
+func (c *Client) Return(method string, value any, err error) *Client {
+	return c.Script(method, Response{Value: value, Err: err})
+}
+
+// Scripts method to return the responses in order, one per call. The last response is
+// returned again for any further call.
+func (c *Client) Script(method string, responses ...Response) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[method] = append(c.responses[method], responses...)
+	return c
+}
+
+// Returns all calls made so far in order.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// Returns the calls made so far to method in order.
+func (c *Client) CallsTo(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []Call
+	for _, call := range c.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Forgets all calls and scripted responses.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+	c.responses = map[string][]Response{}
+}
+
+// record records a call to method and returns its next scripted response.
+func (c *Client) record(method string, args ...any) (string, Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+	responses := c.responses[method]
+	if len(responses) == 0 {
+		return method, Response{Err: fmt.Errorf("%w for %s", ErrNotScripted, method)}
+	}
+	if len(responses) > 1 {
+		c.responses[method] = responses[1:]
+	}
+	return method, responses[0]
+}
+
+// value returns the scripted value of res as T, taking the address of a non-pointer value
+// if T is a pointer to it.
+func value[T any](method string, res Response) (T, error) {
+	var zero T
+	if res.Value == nil {
+		return zero, res.Err
+	}
+	if v, ok := res.Value.(T); ok {
+		return v, res.Err
+	}
+	t := reflect.TypeFor[T]()
+	if v := reflect.ValueOf(res.Value); t.Kind() == reflect.Pointer && v.Type() == t.Elem() {
+		p := reflect.New(t.Elem())
+		p.Elem().Set(v)
+		return p.Interface().(T), res.Err
+	}
+	return zero, fmt.Errorf("qiitamock: %s scripted with %T, want %v", method, res.Value, t)
+}
+
+func pageOf[T any](method string, res Response) (T, *qiita.Page, error) {
+	v, err := value[T](method, res)
+	return v, res.Page, err
+}
+
+// seq yields the elements of the scripted slice of res, and then its error if any.
+func seq[E any](method string, res Response) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		if res.Value != nil {
+			v := reflect.ValueOf(res.Value)
+			if v.Kind() == reflect.Pointer {
+				v = v.Elem()
+			}
+			if v.Kind() != reflect.Slice || v.Type().Elem() != reflect.TypeFor[E]() {
+				yield(zero, fmt.Errorf("qiitamock: %s scripted with %T, want []%v", method, res.Value, reflect.TypeFor[E]()))
+				return
+			}
+			for i := range v.Len() {
+				if !yield(v.Index(i).Interface().(E), nil) {
+					return
+				}
+			}
+		}
+		if res.Err != nil {
+			yield(zero, res.Err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package qiitamock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+func TestReturn(t *testing.T) {
+	m := New()
+	m.Return("GetItem", &qiita.Item{Id: "c686397e4a0f4f11683d", Title: "Example"}, nil)
+	m.Return("StockItem", nil, qiita.ErrNotFound)
+
+	var api qiita.API = m
+	ctx := context.TODO()
+	item, err := api.GetItem(ctx, "c686397e4a0f4f11683d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Title != "Example" {
+		t.Errorf("item.Title = %q", item.Title)
+	}
+	if err := api.StockItem(ctx, "c686397e4a0f4f11683d"); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+	want := []Call{
+		{"GetItem", []any{qiita.ItemId("c686397e4a0f4f11683d")}},
+		{"StockItem", []any{qiita.ItemId("c686397e4a0f4f11683d")}},
+	}
+	if calls := m.Calls(); !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if calls := m.CallsTo("StockItem"); len(calls) != 1 {
+		t.Errorf("calls to StockItem = %v", calls)
+	}
+}
+
+func TestScript(t *testing.T) {
+	m := New().Script("ListItems",
+		Response{Value: qiita.Items{{Title: "first"}}, Page: &qiita.Page{Next: 2}},
+		Response{Err: qiita.ErrRateLimited},
+	)
+	ctx := context.TODO()
+	items, page, err := m.ListItems(ctx, 1, 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*items) != 1 || page.Next != 2 {
+		t.Errorf("items = %v, page = %v", items, page)
+	}
+	for range 2 {
+		if _, _, err := m.ListItems(ctx, 2, 20, ""); !errors.Is(err, qiita.ErrRateLimited) {
+			t.Errorf("err = %v, want ErrRateLimited", err)
+		}
+	}
+}
+
+func TestNotScripted(t *testing.T) {
+	m := New()
+	if _, err := m.GetUser(context.TODO(), "qiita"); !errors.Is(err, ErrNotScripted) {
+		t.Errorf("err = %v, want ErrNotScripted", err)
+	}
+	m.Return("GetUser", &qiita.Tag{}, nil)
+	if _, err := m.GetUser(context.TODO(), "qiita"); err == nil {
+		t.Error("expected an error for a response of the wrong type")
+	}
+	m.Reset()
+	if calls := m.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %v after Reset", calls)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	m := New().Script("AllItems", Response{
+		Value: []qiita.Item{{Title: "first"}, {Title: "second"}},
+		Err:   qiita.ErrRateLimited,
+	})
+	var titles []string
+	var last error
+	for item, err := range m.AllItems(context.TODO(), "tag:go") {
+		if err != nil {
+			last = err
+			break
+		}
+		titles = append(titles, item.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"first", "second"}) || !errors.Is(last, qiita.ErrRateLimited) {
+		t.Errorf("titles = %v, err = %v", titles, last)
+	}
+	if calls := m.CallsTo("AllItems"); len(calls) != 1 || calls[0].Args[0] != "tag:go" {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+// Code under test depends on a narrow service rather than on *qiita.Client.
+func TestService(t *testing.T) {
+	titles := func(ctx context.Context, s qiita.ItemsService, userId qiita.UserId) ([]string, error) {
+		var titles []string
+		for item, err := range s.AllUserItems(ctx, userId) {
+			if err != nil {
+				return nil, err
+			}
+			titles = append(titles, item.Title)
+		}
+		return titles, nil
+	}
+	m := New().Return("AllUserItems", qiita.Items{{Title: "Hello"}}, nil)
+	got, err := titles(context.TODO(), m, "qiita")
+	if err != nil || !reflect.DeepEqual(got, []string{"Hello"}) {
+		t.Errorf("titles = %v, %v", got, err)
+	}
+	if calls := m.CallsTo("AllUserItems"); calls[0].Args[0] != qiita.UserId("qiita") {
+		t.Errorf("calls = %v", calls)
+	}
+}
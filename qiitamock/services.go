@@ -0,0 +1,455 @@
+package qiitamock
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// qiita.ItemsService
+
+func (c *Client) ListAuthenticatedUserItems(ctx context.Context, page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+	return pageOf[*qiita.Items](c.record("ListAuthenticatedUserItems", page, perPage))
+}
+
+func (c *Client) AllAuthenticatedUserItems(ctx context.Context) iter.Seq2[qiita.Item, error] {
+	return seq[qiita.Item](c.record("AllAuthenticatedUserItems"))
+}
+
+func (c *Client) ListItems(ctx context.Context, page, perPage uint, query string) (*qiita.Items, *qiita.Page, error) {
+	return pageOf[*qiita.Items](c.record("ListItems", page, perPage, query))
+}
+
+func (c *Client) AllItems(ctx context.Context, query string) iter.Seq2[qiita.Item, error] {
+	return seq[qiita.Item](c.record("AllItems", query))
+}
+
+func (c *Client) CreateItem(ctx context.Context, item qiita.Item) (*qiita.Item, error) {
+	return value[*qiita.Item](c.record("CreateItem", item))
+}
+
+func (c *Client) DeleteItem(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("DeleteItem", itemId)
+	return res.Err
+}
+
+func (c *Client) GetItem(ctx context.Context, itemId qiita.ItemId) (*qiita.Item, error) {
+	return value[*qiita.Item](c.record("GetItem", itemId))
+}
+
+func (c *Client) UpdateItem(ctx context.Context, item qiita.Item) (*qiita.Item, error) {
+	return value[*qiita.Item](c.record("UpdateItem", item))
+}
+
+func (c *Client) UnlikeItem(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("UnlikeItem", itemId)
+	return res.Err
+}
+
+func (c *Client) LikeItem(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("LikeItem", itemId)
+	return res.Err
+}
+
+func (c *Client) StockItem(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("StockItem", itemId)
+	return res.Err
+}
+
+func (c *Client) UnstockItem(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("UnstockItem", itemId)
+	return res.Err
+}
+
+func (c *Client) EnsureItemStock(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("EnsureItemStock", itemId)
+	return res.Err
+}
+
+func (c *Client) EnsureItemLike(ctx context.Context, itemId qiita.ItemId) error {
+	_, res := c.record("EnsureItemLike", itemId)
+	return res.Err
+}
+
+func (c *Client) ListTaggedItems(ctx context.Context, tagId qiita.TagId, page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+	return pageOf[*qiita.Items](c.record("ListTaggedItems", tagId, page, perPage))
+}
+
+func (c *Client) AllTaggedItems(ctx context.Context, tagId qiita.TagId) iter.Seq2[qiita.Item, error] {
+	return seq[qiita.Item](c.record("AllTaggedItems", tagId))
+}
+
+func (c *Client) ListUserItems(ctx context.Context, userId qiita.UserId, page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+	return pageOf[*qiita.Items](c.record("ListUserItems", userId, page, perPage))
+}
+
+func (c *Client) AllUserItems(ctx context.Context, userId qiita.UserId) iter.Seq2[qiita.Item, error] {
+	return seq[qiita.Item](c.record("AllUserItems", userId))
+}
+
+func (c *Client) ListUserStocks(ctx context.Context, userId qiita.UserId, page, perPage uint) (*qiita.Items, *qiita.Page, error) {
+	return pageOf[*qiita.Items](c.record("ListUserStocks", userId, page, perPage))
+}
+
+func (c *Client) AllUserStocks(ctx context.Context, userId qiita.UserId) iter.Seq2[qiita.Item, error] {
+	return seq[qiita.Item](c.record("AllUserStocks", userId))
+}
+
+func (c *Client) ListItemLikes(ctx context.Context, itemId qiita.ItemId) (*qiita.Likes, error) {
+	return value[*qiita.Likes](c.record("ListItemLikes", itemId))
+}
+
+func (c *Client) AddItemTagging(ctx context.Context, itemId qiita.ItemId, tagging qiita.Tagging) (*qiita.Tagging, error) {
+	return value[*qiita.Tagging](c.record("AddItemTagging", itemId, tagging))
+}
+
+func (c *Client) DeleteItemTagging(ctx context.Context, itemId qiita.ItemId, taggingId string) error {
+	_, res := c.record("DeleteItemTagging", itemId, taggingId)
+	return res.Err
+}
+
+// qiita.UsersService
+
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*qiita.AuthenticatedUser, error) {
+	return value[*qiita.AuthenticatedUser](c.record("GetAuthenticatedUser"))
+}
+
+func (c *Client) ListStockers(ctx context.Context, itemId qiita.ItemId, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+	return pageOf[*qiita.Users](c.record("ListStockers", itemId, page, perPage))
+}
+
+func (c *Client) AllStockers(ctx context.Context, itemId qiita.ItemId) iter.Seq2[qiita.User, error] {
+	return seq[qiita.User](c.record("AllStockers", itemId))
+}
+
+func (c *Client) ListUsers(ctx context.Context, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+	return pageOf[*qiita.Users](c.record("ListUsers", page, perPage))
+}
+
+func (c *Client) AllUsers(ctx context.Context) iter.Seq2[qiita.User, error] {
+	return seq[qiita.User](c.record("AllUsers"))
+}
+
+func (c *Client) GetUser(ctx context.Context, userId qiita.UserId) (*qiita.User, error) {
+	return value[*qiita.User](c.record("GetUser", userId))
+}
+
+func (c *Client) ListFollowees(ctx context.Context, userId qiita.UserId, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+	return pageOf[*qiita.Users](c.record("ListFollowees", userId, page, perPage))
+}
+
+func (c *Client) AllFollowees(ctx context.Context, userId qiita.UserId) iter.Seq2[qiita.User, error] {
+	return seq[qiita.User](c.record("AllFollowees", userId))
+}
+
+func (c *Client) ListFollowers(ctx context.Context, userId qiita.UserId, page, perPage uint) (*qiita.Users, *qiita.Page, error) {
+	return pageOf[*qiita.Users](c.record("ListFollowers", userId, page, perPage))
+}
+
+func (c *Client) AllFollowers(ctx context.Context, userId qiita.UserId) iter.Seq2[qiita.User, error] {
+	return seq[qiita.User](c.record("AllFollowers", userId))
+}
+
+func (c *Client) UnfollowUser(ctx context.Context, userId qiita.UserId) error {
+	_, res := c.record("UnfollowUser", userId)
+	return res.Err
+}
+
+func (c *Client) EnsureFollowingUser(ctx context.Context, userId qiita.UserId) error {
+	_, res := c.record("EnsureFollowingUser", userId)
+	return res.Err
+}
+
+func (c *Client) FollowUser(ctx context.Context, userId qiita.UserId) error {
+	_, res := c.record("FollowUser", userId)
+	return res.Err
+}
+
+// qiita.TagsService
+
+func (c *Client) ListTags(ctx context.Context, page, perPage uint, sort string) (*qiita.Tags, *qiita.Page, error) {
+	return pageOf[*qiita.Tags](c.record("ListTags", page, perPage, sort))
+}
+
+func (c *Client) AllTags(ctx context.Context, sort string) iter.Seq2[qiita.Tag, error] {
+	return seq[qiita.Tag](c.record("AllTags", sort))
+}
+
+func (c *Client) GetTag(ctx context.Context, tagId qiita.TagId) (*qiita.Tag, error) {
+	return value[*qiita.Tag](c.record("GetTag", tagId))
+}
+
+func (c *Client) ListFollowingTags(ctx context.Context, userId qiita.UserId, page, perPage uint) (*qiita.Tags, *qiita.Page, error) {
+	return pageOf[*qiita.Tags](c.record("ListFollowingTags", userId, page, perPage))
+}
+
+func (c *Client) AllFollowingTags(ctx context.Context, userId qiita.UserId) iter.Seq2[qiita.Tag, error] {
+	return seq[qiita.Tag](c.record("AllFollowingTags", userId))
+}
+
+func (c *Client) UnfollowTag(ctx context.Context, tagId qiita.TagId) error {
+	_, res := c.record("UnfollowTag", tagId)
+	return res.Err
+}
+
+func (c *Client) EnsureFollowingTag(ctx context.Context, tagId qiita.TagId) error {
+	_, res := c.record("EnsureFollowingTag", tagId)
+	return res.Err
+}
+
+func (c *Client) FollowTag(ctx context.Context, tagId qiita.TagId) error {
+	_, res := c.record("FollowTag", tagId)
+	return res.Err
+}
+
+// qiita.CommentsService
+
+func (c *Client) DeleteComment(ctx context.Context, commentId qiita.CommentId) error {
+	_, res := c.record("DeleteComment", commentId)
+	return res.Err
+}
+
+func (c *Client) GetComment(ctx context.Context, commentId qiita.CommentId) (*qiita.Comment, error) {
+	return value[*qiita.Comment](c.record("GetComment", commentId))
+}
+
+func (c *Client) UpdateComment(ctx context.Context, comment qiita.Comment) (*qiita.Comment, error) {
+	return value[*qiita.Comment](c.record("UpdateComment", comment))
+}
+
+func (c *Client) ListComments(ctx context.Context, itemId qiita.ItemId) (*qiita.Comments, error) {
+	return value[*qiita.Comments](c.record("ListComments", itemId))
+}
+
+func (c *Client) PostComment(ctx context.Context, itemId qiita.ItemId, comment qiita.Comment) (*qiita.Comment, error) {
+	return value[*qiita.Comment](c.record("PostComment", itemId, comment))
+}
+
+func (c *Client) ListProjectComments(ctx context.Context, projectId qiita.ProjectId, page, perPage uint) (*qiita.Comments, *qiita.Page, error) {
+	return pageOf[*qiita.Comments](c.record("ListProjectComments", projectId, page, perPage))
+}
+
+func (c *Client) AllProjectComments(ctx context.Context, projectId qiita.ProjectId) iter.Seq2[qiita.Comment, error] {
+	return seq[qiita.Comment](c.record("AllProjectComments", projectId))
+}
+
+func (c *Client) PostProjectComment(ctx context.Context, projectId qiita.ProjectId, comment qiita.Comment) (*qiita.Comment, error) {
+	return value[*qiita.Comment](c.record("PostProjectComment", projectId, comment))
+}
+
+// qiita.ReactionsService
+
+func (c *Client) AddCommentReaction(ctx context.Context, commentId qiita.CommentId, reaction qiita.Reaction) (*qiita.Reaction, error) {
+	return value[*qiita.Reaction](c.record("AddCommentReaction", commentId, reaction))
+}
+
+func (c *Client) AddItemReaction(ctx context.Context, itemId qiita.ItemId, reaction qiita.Reaction) (*qiita.Reaction, error) {
+	return value[*qiita.Reaction](c.record("AddItemReaction", itemId, reaction))
+}
+
+func (c *Client) AddProjectReaction(ctx context.Context, projectId qiita.ProjectId, reaction qiita.Reaction) (*qiita.Reaction, error) {
+	return value[*qiita.Reaction](c.record("AddProjectReaction", projectId, reaction))
+}
+
+func (c *Client) DeleteCommentReaction(ctx context.Context, commentId qiita.CommentId, reactionName string) error {
+	_, res := c.record("DeleteCommentReaction", commentId, reactionName)
+	return res.Err
+}
+
+func (c *Client) DeleteItemReaction(ctx context.Context, itemId qiita.ItemId, reactionName string) error {
+	_, res := c.record("DeleteItemReaction", itemId, reactionName)
+	return res.Err
+}
+
+func (c *Client) DeleteProjectReaction(ctx context.Context, projectId qiita.ProjectId, reactionName string) error {
+	_, res := c.record("DeleteProjectReaction", projectId, reactionName)
+	return res.Err
+}
+
+func (c *Client) ListCommentReactions(ctx context.Context, commentId qiita.CommentId) (*qiita.Reactions, error) {
+	return value[*qiita.Reactions](c.record("ListCommentReactions", commentId))
+}
+
+func (c *Client) ListItemReactions(ctx context.Context, itemId qiita.ItemId) (*qiita.Reactions, error) {
+	return value[*qiita.Reactions](c.record("ListItemReactions", itemId))
+}
+
+func (c *Client) ListProjectReactions(ctx context.Context, projectId qiita.ProjectId) (*qiita.Reactions, error) {
+	return value[*qiita.Reactions](c.record("ListProjectReactions", projectId))
+}
+
+// qiita.ProjectsService
+
+func (c *Client) ListProjects(ctx context.Context, page, perPage uint) (*qiita.Projects, *qiita.Page, error) {
+	return pageOf[*qiita.Projects](c.record("ListProjects", page, perPage))
+}
+
+func (c *Client) AllProjects(ctx context.Context) iter.Seq2[qiita.Project, error] {
+	return seq[qiita.Project](c.record("AllProjects"))
+}
+
+func (c *Client) CreateProject(ctx context.Context, project qiita.Project) (*qiita.Project, error) {
+	return value[*qiita.Project](c.record("CreateProject", project))
+}
+
+func (c *Client) DeleteProject(ctx context.Context, projectId qiita.ProjectId) error {
+	_, res := c.record("DeleteProject", projectId)
+	return res.Err
+}
+
+func (c *Client) GetProject(ctx context.Context, projectId qiita.ProjectId) (*qiita.Project, error) {
+	return value[*qiita.Project](c.record("GetProject", projectId))
+}
+
+func (c *Client) UpdateProject(ctx context.Context, project qiita.Project) (*qiita.Project, error) {
+	return value[*qiita.Project](c.record("UpdateProject", project))
+}
+
+// qiita.TemplatesService
+
+func (c *Client) ListTemplates(ctx context.Context, page, perPage uint) (*qiita.Templates, *qiita.Page, error) {
+	return pageOf[*qiita.Templates](c.record("ListTemplates", page, perPage))
+}
+
+func (c *Client) AllTemplates(ctx context.Context) iter.Seq2[qiita.Template, error] {
+	return seq[qiita.Template](c.record("AllTemplates"))
+}
+
+func (c *Client) DeleteTemplate(ctx context.Context, templateId qiita.TemplateId) error {
+	_, res := c.record("DeleteTemplate", templateId)
+	return res.Err
+}
+
+func (c *Client) GetTemplate(ctx context.Context, templateId qiita.TemplateId) (*qiita.Template, error) {
+	return value[*qiita.Template](c.record("GetTemplate", templateId))
+}
+
+func (c *Client) CreateTemplate(ctx context.Context, template qiita.Template) (*qiita.Template, error) {
+	return value[*qiita.Template](c.record("CreateTemplate", template))
+}
+
+func (c *Client) UpdateTemplate(ctx context.Context, template qiita.Template) (*qiita.Template, error) {
+	return value[*qiita.Template](c.record("UpdateTemplate", template))
+}
+
+func (c *Client) CreateExpandedTemplate(ctx context.Context, template qiita.Template) (*qiita.ExpandedTemplate, error) {
+	return value[*qiita.ExpandedTemplate](c.record("CreateExpandedTemplate", template))
+}
+
+// qiita.GroupsService
+
+func (c *Client) ListGroups(ctx context.Context, page, perPage uint) (*qiita.Groups, *qiita.Page, error) {
+	return pageOf[*qiita.Groups](c.record("ListGroups", page, perPage))
+}
+
+func (c *Client) AllGroups(ctx context.Context) iter.Seq2[qiita.Group, error] {
+	return seq[qiita.Group](c.record("AllGroups"))
+}
+
+func (c *Client) GetGroup(ctx context.Context, urlName string) (*qiita.Group, error) {
+	return value[*qiita.Group](c.record("GetGroup", urlName))
+}
+
+func (c *Client) CreateGroup(ctx context.Context, group qiita.Group) (*qiita.Group, error) {
+	return value[*qiita.Group](c.record("CreateGroup", group))
+}
+
+func (c *Client) UpdateGroup(ctx context.Context, group qiita.Group) (*qiita.Group, error) {
+	return value[*qiita.Group](c.record("UpdateGroup", group))
+}
+
+func (c *Client) DeleteGroup(ctx context.Context, urlName string) error {
+	_, res := c.record("DeleteGroup", urlName)
+	return res.Err
+}
+
+func (c *Client) ListGroupMembers(ctx context.Context, urlName string, page, perPage uint) (*qiita.GroupMembers, *qiita.Page, error) {
+	return pageOf[*qiita.GroupMembers](c.record("ListGroupMembers", urlName, page, perPage))
+}
+
+func (c *Client) AllGroupMembers(ctx context.Context, urlName string) iter.Seq2[qiita.GroupMember, error] {
+	return seq[qiita.GroupMember](c.record("AllGroupMembers", urlName))
+}
+
+func (c *Client) AddGroupMember(ctx context.Context, urlName string, member qiita.GroupMember) (*qiita.GroupMember, error) {
+	return value[*qiita.GroupMember](c.record("AddGroupMember", urlName, member))
+}
+
+func (c *Client) UpdateGroupMember(ctx context.Context, urlName string, member qiita.GroupMember) (*qiita.GroupMember, error) {
+	return value[*qiita.GroupMember](c.record("UpdateGroupMember", urlName, member))
+}
+
+func (c *Client) RemoveGroupMember(ctx context.Context, urlName string, userId qiita.UserId) error {
+	_, res := c.record("RemoveGroupMember", urlName, userId)
+	return res.Err
+}
+
+// qiita.TeamService
+
+func (c *Client) ListTeams(ctx context.Context) (*qiita.Teams, error) {
+	return value[*qiita.Teams](c.record("ListTeams"))
+}
+
+func (c *Client) GetTeamMembership(ctx context.Context) (*qiita.TeamMembership, error) {
+	return value[*qiita.TeamMembership](c.record("GetTeamMembership"))
+}
+
+func (c *Client) ListTeamInvitations(ctx context.Context, page, perPage uint) (*qiita.TeamInvitations, *qiita.Page, error) {
+	return pageOf[*qiita.TeamInvitations](c.record("ListTeamInvitations", page, perPage))
+}
+
+func (c *Client) AllTeamInvitations(ctx context.Context) iter.Seq2[qiita.TeamInvitation, error] {
+	return seq[qiita.TeamInvitation](c.record("AllTeamInvitations"))
+}
+
+func (c *Client) CreateTeamInvitation(ctx context.Context, invitation qiita.TeamInvitation) (*qiita.TeamInvitation, error) {
+	return value[*qiita.TeamInvitation](c.record("CreateTeamInvitation", invitation))
+}
+
+func (c *Client) RevokeTeamInvitation(ctx context.Context, email string) error {
+	_, res := c.record("RevokeTeamInvitation", email)
+	return res.Err
+}
+
+func (c *Client) ListTeamAccessTokens(ctx context.Context, page, perPage uint) (*qiita.TeamAccessTokens, *qiita.Page, error) {
+	return pageOf[*qiita.TeamAccessTokens](c.record("ListTeamAccessTokens", page, perPage))
+}
+
+func (c *Client) AllTeamAccessTokens(ctx context.Context) iter.Seq2[qiita.TeamAccessToken, error] {
+	return seq[qiita.TeamAccessToken](c.record("AllTeamAccessTokens"))
+}
+
+func (c *Client) CreateTeamAccessToken(ctx context.Context, token qiita.TeamAccessToken) (*qiita.TeamAccessToken, error) {
+	return value[*qiita.TeamAccessToken](c.record("CreateTeamAccessToken", token))
+}
+
+func (c *Client) RevokeTeamAccessToken(ctx context.Context, accessToken string) error {
+	_, res := c.record("RevokeTeamAccessToken", accessToken)
+	return res.Err
+}
+
+// qiita.AccessTokensService
+
+func (c *Client) CreateAccessToken(ctx context.Context, auth qiita.Auth) (*qiita.AccessToken, error) {
+	return value[*qiita.AccessToken](c.record("CreateAccessToken", auth))
+}
+
+func (c *Client) DeleteAccessToken(ctx context.Context, accessToken string) error {
+	_, res := c.record("DeleteAccessToken", accessToken)
+	return res.Err
+}
+
+func (c *Client) AuthCodeURL(config qiita.OAuthConfig, state string) string {
+	v, _ := value[string](c.record("AuthCodeURL", config, state))
+	return v
+}
+
+func (c *Client) ExchangeOAuthCode(ctx context.Context, config qiita.OAuthConfig, code string) (*qiita.AccessToken, error) {
+	return value[*qiita.AccessToken](c.record("ExchangeOAuthCode", config, code))
+}
+
+func (c *Client) HandleOAuthCallback(ctx context.Context, config qiita.OAuthConfig, r *http.Request, state string) (*qiita.AccessToken, error) {
+	return value[*qiita.AccessToken](c.record("HandleOAuthCallback", config, r, state))
+}
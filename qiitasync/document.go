@@ -0,0 +1,312 @@
+package qiitasync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/internal/yamlscalar"
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Returned from ParseDocument for a file which does not start with front matter.
+var ErrNoFrontMatter = errors.New("qiitasync: no front matter")
+
+// Metadata of an item in the front matter of a Markdown file:
+//
+//	---
+//	title: Context in Go
+//	tags:
+//	  - name: Go
+//	    versions: [1.22, 1.23]
+//	  - concurrency
+//	private: false
+//	coediting: false
+//	group: dev
+//	id: c686397e4a0f4f11683d
+//	updated_at: '2024-06-01T12:00:00+09:00'
+//	---
+//
+// A tag is either a name or a mapping of a name and versions. The id and updated_at of a file are written
+// by the synchronization: updated_at is the time the item was updated at when it was last synchronized.
+// Other keys are ignored.
+type FrontMatter struct {
+	Title               string
+	Tags                qiita.Taggings
+	Private             bool
+	Coediting           bool
+	Group               string // url_name of a group on Qiita:Team
+	OrganizationUrlName string
+	Slide               bool
+	Id                  qiita.ItemId
+	UpdatedAt           qiita.Time
+}
+
+// A Markdown file with front matter.
+type Document struct {
+	FrontMatter
+	Body string
+
+	raw []byte
+}
+
+// Parses a Markdown file starting with front matter between "---" lines.
+func ParseDocument(b []byte) (*Document, error) {
+	_, fm, tail, ok := splitFrontMatter(b)
+	if !ok {
+		return nil, ErrNoFrontMatter
+	}
+	m, err := parseYAML(string(fm))
+	if err != nil {
+		return nil, err
+	}
+	d := &Document{raw: b}
+	if err := d.FrontMatter.decode(m); err != nil {
+		return nil, err
+	}
+	_, body, _ := bytes.Cut(tail, []byte("\n"))
+	d.Body = string(body)
+	return d, nil
+}
+
+// splitFrontMatter splits b into the opening delimiter line, the front matter, and the rest starting
+// with the closing delimiter line.
+func splitFrontMatter(b []byte) (head, fm, tail []byte, ok bool) {
+	first, rest, found := bytes.Cut(b, []byte("\n"))
+	if !found || string(bytes.TrimRight(first, " \r")) != "---" {
+		return nil, nil, nil, false
+	}
+	head = b[:len(first)+1]
+	for i := 0; i < len(rest); {
+		line, _, _ := bytes.Cut(rest[i:], []byte("\n"))
+		if d := string(bytes.TrimRight(line, " \r")); d == "---" || d == "..." {
+			return head, rest[:i], rest[i:], true
+		}
+		i += len(line) + 1
+	}
+	return nil, nil, nil, false
+}
+
+func (fm *FrontMatter) decode(m map[string]any) error {
+	var err error
+	for key, v := range m {
+		switch key {
+		case "title":
+			fm.Title, err = stringValue(key, v)
+		case "tags":
+			fm.Tags, err = tagsValue(v)
+		case "private":
+			fm.Private, err = boolValue(key, v)
+		case "coediting":
+			fm.Coediting, err = boolValue(key, v)
+		case "group":
+			fm.Group, err = stringValue(key, v)
+		case "organization_url_name":
+			fm.OrganizationUrlName, err = stringValue(key, v)
+		case "slide":
+			fm.Slide, err = boolValue(key, v)
+		case "id":
+			var id string
+			id, err = stringValue(key, v)
+			fm.Id = qiita.ItemId(id)
+		case "updated_at":
+			var s string
+			if s, err = stringValue(key, v); err == nil && s != "" {
+				var t time.Time
+				if t, err = time.Parse(time.RFC3339, s); err != nil {
+					err = fmt.Errorf("front matter: invalid updated_at %q", s)
+				}
+				fm.UpdatedAt = qiita.Time{Time: t}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stringValue(key string, v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("front matter: %s is not a string", key)
+}
+
+func boolValue(key string, v any) (bool, error) {
+	switch v {
+	case nil, "false":
+		return false, nil
+	case "true":
+		return true, nil
+	}
+	return false, fmt.Errorf("front matter: %s is not true or false", key)
+}
+
+func tagsValue(v any) (qiita.Taggings, error) {
+	if v == nil {
+		return nil, nil
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("front matter: tags is not a list")
+	}
+	tags := qiita.Taggings{}
+	for _, e := range list {
+		switch e := e.(type) {
+		case string:
+			tags = append(tags, qiita.Tagging{Name: e})
+		case map[string]any:
+			name, err := stringValue("tag name", e["name"])
+			if err != nil || name == "" {
+				return nil, errors.New("front matter: a tag has no name")
+			}
+			tag := qiita.Tagging{Name: name}
+			switch versions := e["versions"].(type) {
+			case nil:
+			case string:
+				tag.Versions = []string{versions}
+			case []any:
+				for _, version := range versions {
+					s, err := stringValue("tag version", version)
+					if err != nil {
+						return nil, err
+					}
+					tag.Versions = append(tag.Versions, s)
+				}
+			default:
+				return nil, fmt.Errorf("front matter: versions of tag %s is not a list", name)
+			}
+			tags = append(tags, tag)
+		default:
+			return nil, errors.New("front matter: a tag is neither a name nor a mapping")
+		}
+	}
+	return tags, nil
+}
+
+// Returns the item written in the document.
+func (d *Document) Item() qiita.Item {
+	return qiita.Item{
+		Body:                d.Body,
+		Coediting:           d.Coediting,
+		GroupUrlName:        d.Group,
+		Id:                  d.Id,
+		OrganizationUrlName: d.OrganizationUrlName,
+		Private:             d.Private,
+		Slide:               d.Slide,
+		Tags:                d.Tags,
+		Title:               d.Title,
+	}
+}
+
+// Returns a document of an item, whose front matter has the metadata of the item, e.g. to save the item
+// as a Markdown file which can be synchronized.
+func NewDocument(item qiita.Item) *Document {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("title: " + yamlscalar.String(item.Title) + "\n")
+	b.WriteString("tags:")
+	if len(item.Tags) == 0 {
+		b.WriteString(" []")
+	}
+	b.WriteString("\n")
+	for _, tag := range item.Tags {
+		if len(tag.Versions) == 0 {
+			b.WriteString("  - " + yamlscalar.String(tag.Name) + "\n")
+			continue
+		}
+		b.WriteString("  - name: " + yamlscalar.String(tag.Name) + "\n")
+		b.WriteString("    versions:\n")
+		for _, version := range tag.Versions {
+			b.WriteString("      - " + yamlscalar.String(version) + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "private: %t\n", item.Private)
+	fmt.Fprintf(&b, "coediting: %t\n", item.Coediting)
+	if item.Group != nil && item.Group.UrlName != "" {
+		b.WriteString("group: " + yamlscalar.String(item.Group.UrlName) + "\n")
+	}
+	if item.OrganizationUrlName != "" {
+		b.WriteString("organization_url_name: " + yamlscalar.String(item.OrganizationUrlName) + "\n")
+	}
+	if item.Slide {
+		b.WriteString("slide: true\n")
+	}
+	if item.Id != "" {
+		b.WriteString("id: " + yamlscalar.String(string(item.Id)) + "\n")
+	}
+	if !item.UpdatedAt.IsZero() {
+		b.WriteString("updated_at: " + yamlscalar.String(item.UpdatedAt.String()) + "\n")
+	}
+	b.WriteString("---\n")
+	b.WriteString(item.Body)
+
+	d := &Document{Body: item.Body, raw: []byte(b.String())}
+	d.Title, d.Tags, d.Private, d.Coediting = item.Title, item.Tags, item.Private, item.Coediting
+	if item.Group != nil {
+		d.Group = item.Group.UrlName
+	}
+	d.OrganizationUrlName, d.Slide, d.Id, d.UpdatedAt = item.OrganizationUrlName, item.Slide, item.Id, item.UpdatedAt
+	return d
+}
+
+// Returns the contents of the file, including the changes made by the synchronization.
+func (d *Document) Bytes() []byte {
+	return d.raw
+}
+
+// setSynced records the id and the updated_at of the item the document was synchronized with.
+func (d *Document) setSynced(item *qiita.Item) {
+	if d.Id != item.Id {
+		d.Id = item.Id
+		d.set("id", yamlscalar.String(string(item.Id)))
+	}
+	if !d.UpdatedAt.Equal(item.UpdatedAt.Time) {
+		d.UpdatedAt = item.UpdatedAt
+		d.set("updated_at", yamlscalar.String(item.UpdatedAt.String()))
+	}
+}
+
+// set rewrites the line of a top-level key in the front matter, or adds the key at its end, leaving the
+// rest of the file as it is.
+func (d *Document) set(key, value string) {
+	head, fm, tail, _ := splitFrontMatter(d.raw)
+	nl := "\n"
+	if bytes.HasSuffix(head, []byte("\r\n")) {
+		nl = "\r\n"
+	}
+	entry := key + ": " + value + nl
+
+	var b bytes.Buffer
+	b.Write(head)
+	replaced, skipping := false, false
+	for line := range strings.Lines(string(fm)) {
+		if skipping && strings.HasPrefix(line, " ") {
+			// The value of the replaced key continued on this line.
+			continue
+		}
+		skipping = false
+		text := strings.TrimRight(line, " \r\n")
+		if text == "" || text[0] == ' ' || text[0] == '#' || replaced {
+			b.WriteString(line)
+			continue
+		}
+		if k, _, ok, _ := cutKey(text); ok && k == key {
+			b.WriteString(entry)
+			replaced, skipping = true, true
+			continue
+		}
+		b.WriteString(line)
+	}
+	if !replaced {
+		b.WriteString(entry)
+	}
+	b.Write(tail)
+	d.raw = b.Bytes()
+}
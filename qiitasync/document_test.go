@@ -0,0 +1,139 @@
+package qiitasync
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+const article = `---
+# Written by hand
+title: Context in Go
+tags:
+  - name: Go
+    versions: [1.22]
+  - concurrency
+private: true
+group: dev
+---
+# Context in Go
+
+Cancel goroutines.
+`
+
+func TestParseDocument(t *testing.T) {
+	d, err := ParseDocument([]byte(article))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FrontMatter{
+		Title:   "Context in Go",
+		Tags:    qiita.Taggings{{Name: "Go", Versions: []string{"1.22"}}, {Name: "concurrency"}},
+		Private: true,
+		Group:   "dev",
+	}
+	if !reflect.DeepEqual(d.FrontMatter, want) {
+		t.Errorf("front matter = %+v, want %+v", d.FrontMatter, want)
+	}
+	if d.Body != "# Context in Go\n\nCancel goroutines.\n" {
+		t.Errorf("body = %q", d.Body)
+	}
+	if item := d.Item(); item.Title != want.Title || item.GroupUrlName != "dev" || !item.Private || item.Body != d.Body {
+		t.Errorf("item = %+v", item)
+	}
+
+	for _, src := range []string{"# No front matter\n", "---\ntitle: unterminated\n", ""} {
+		if _, err := ParseDocument([]byte(src)); !errors.Is(err, ErrNoFrontMatter) {
+			t.Errorf("%q: err = %v, want ErrNoFrontMatter", src, err)
+		}
+	}
+	for _, src := range []string{
+		"---\nprivate: maybe\n---\n",
+		"---\ntags: Go\n---\n",
+		"---\ntags:\n  - versions: [1]\n---\n",
+		"---\nupdated_at: yesterday\n---\n",
+		"---\ntitle: [a]\n---\n",
+	} {
+		if _, err := ParseDocument([]byte(src)); err == nil || errors.Is(err, ErrNoFrontMatter) {
+			t.Errorf("%q: err = %v, want a front matter error", src, err)
+		}
+	}
+}
+
+func TestSetSynced(t *testing.T) {
+	d, err := ParseDocument([]byte(article))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updatedAt := qiita.Time{Time: time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("", 9*60*60))}
+	d.setSynced(&qiita.Item{Id: "c686397e4a0f4f11683d", UpdatedAt: updatedAt})
+	want := `---
+# Written by hand
+title: Context in Go
+tags:
+  - name: Go
+    versions: [1.22]
+  - concurrency
+private: true
+group: dev
+id: c686397e4a0f4f11683d
+updated_at: 2024-06-01T12:00:00+09:00
+---
+# Context in Go
+
+Cancel goroutines.
+`
+	if string(d.Bytes()) != want {
+		t.Errorf("file =\n%s\nwant\n%s", d.Bytes(), want)
+	}
+
+	// The existing keys are rewritten in place.
+	d, _ = ParseDocument([]byte("---\r\nid: old\r\nupdated_at:\r\ntitle: x\r\n---\r\nbody\r\n"))
+	d.setSynced(&qiita.Item{Id: "c686397e4a0f4f11683d", UpdatedAt: updatedAt})
+	if want := "---\r\nid: c686397e4a0f4f11683d\r\nupdated_at: 2024-06-01T12:00:00+09:00\r\ntitle: x\r\n---\r\nbody\r\n"; string(d.Bytes()) != want {
+		t.Errorf("file = %q, want %q", d.Bytes(), want)
+	}
+	if d, err := ParseDocument(d.Bytes()); err != nil || d.Id != "c686397e4a0f4f11683d" || !d.UpdatedAt.Equal(updatedAt.Time) {
+		t.Errorf("reparsed %+v, %v", d, err)
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	item := qiita.Item{
+		Body:      "# It's\n",
+		Group:     &qiita.Group{UrlName: "dev"},
+		Id:        "c686397e4a0f4f11683d",
+		Tags:      qiita.Taggings{{Name: "Go", Versions: []string{"1.22"}}, {Name: "C#"}},
+		Title:     "It's: a title",
+		UpdatedAt: qiita.Time{Time: time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("", 9*60*60))},
+	}
+	d := NewDocument(item)
+	want := `---
+title: "It's: a title"
+tags:
+  - name: Go
+    versions:
+      - "1.22"
+  - C#
+private: false
+coediting: false
+group: dev
+id: c686397e4a0f4f11683d
+updated_at: 2024-06-01T12:00:00+09:00
+---
+# It's
+`
+	if string(d.Bytes()) != want {
+		t.Errorf("file =\n%s\nwant\n%s", d.Bytes(), want)
+	}
+	parsed, err := ParseDocument(d.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed.FrontMatter, d.FrontMatter) || parsed.Body != d.Body || !reflect.DeepEqual(parsed.Item(), d.Item()) {
+		t.Errorf("parsed %+v, want %+v", parsed, d)
+	}
+}
@@ -0,0 +1,277 @@
+// Package qiitasync synchronizes a directory of Markdown files with Qiita items.
+//
+// Each Markdown file has the title, tags and other metadata of its item in YAML front matter, as
+// described in FrontMatter. A file without an id is posted as a new item, and the id assigned to it is
+// written back into its front matter. A file with an id updates its item if they differ.
+//
+// The front matter also records the updated_at of the item at the last synchronization. If the item has
+// been edited on Qiita since then, the file is reported as a conflict instead of overwriting the edit,
+// unless the syncer is forced:
+//
+//	s := qiitasync.NewSyncer(client, "articles")
+//	plan, err := s.Plan(ctx)
+//	fmt.Print(plan) // dry run
+//	err = s.Apply(ctx, plan)
+package qiitasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// What the synchronization does to a file and its item.
+type Action int
+
+const (
+	Unchanged Action = iota
+	Create
+	Update
+	Conflict
+)
+
+var actionNames = [...]string{"unchanged", "create", "update", "conflict"}
+
+func (a Action) String() string {
+	if int(a) < len(actionNames) {
+		return actionNames[a]
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// A planned change of a Markdown file and its item.
+type Change struct {
+	Path   string // slash-separated path relative to the directory
+	Action Action
+	Fields []string // front matter fields and "body" differing from the item to update
+	Reason string   // why a conflict is not applied
+
+	Local  *Document
+	Remote *qiita.Item // nil for a new item
+}
+
+// Changes of the Markdown files of a directory, in the order of their paths.
+type Plan []Change
+
+// Formats the plan as a table, e.g. for a dry run.
+func (p Plan) String() string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, c := range p {
+		var detail string
+		switch c.Action {
+		case Create:
+			detail = fmt.Sprintf("%q", c.Local.Title)
+		case Update:
+			detail = fmt.Sprintf("%s (%s)", c.Local.Id, strings.Join(c.Fields, ", "))
+		case Conflict:
+			detail = fmt.Sprintf("%s: %s", c.Local.Id, c.Reason)
+		default:
+			detail = string(c.Local.Id)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Action, c.Path, detail)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// Synchronizes the Markdown files of a directory with items.
+type Syncer struct {
+	client qiita.ItemsService
+	dir    string
+	force  bool
+}
+
+func NewSyncer(client qiita.ItemsService, dir string) *Syncer {
+	return &Syncer{client: client, dir: dir}
+}
+
+// Overwrites items edited on Qiita since the last synchronization, and posts again the items deleted on
+// Qiita, instead of reporting conflicts.
+func (s *Syncer) WithForce(force bool) *Syncer {
+	s.force = force
+	return s
+}
+
+// Compares the Markdown files having front matter in the directory and its subdirectories with their
+// items, without changing anything. Hidden directories such as .git are skipped.
+func (s *Syncer) Plan(ctx context.Context) (Plan, error) {
+	var plan Plan
+	paths := map[qiita.ItemId]string{}
+	err := fs.WalkDir(os.DirFS(s.dir), ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != "." && strings.HasPrefix(entry.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path.Ext(p) != ".md" {
+			return nil
+		}
+		b, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(p)))
+		if err != nil {
+			return err
+		}
+		doc, err := ParseDocument(b)
+		if errors.Is(err, ErrNoFrontMatter) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		if doc.Id != "" {
+			if other, dup := paths[doc.Id]; dup {
+				return fmt.Errorf("%s: id %s is also in %s", p, doc.Id, other)
+			}
+			paths[doc.Id] = p
+		}
+		c, err := s.compare(ctx, p, doc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		plan = append(plan, *c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (s *Syncer) compare(ctx context.Context, p string, doc *Document) (*Change, error) {
+	c := &Change{Path: p, Action: Create, Local: doc}
+	if doc.Id == "" {
+		return c, nil
+	}
+	remote, err := s.client.GetItem(ctx, doc.Id)
+	if errors.Is(err, qiita.ErrNotFound) {
+		if !s.force {
+			c.Action, c.Reason = Conflict, "deleted on Qiita"
+		}
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.Remote = remote
+	c.Fields = diff(doc, remote)
+	switch {
+	case len(c.Fields) == 0:
+		c.Action = Unchanged
+	case s.force:
+		c.Action = Update
+	case doc.UpdatedAt.IsZero():
+		c.Action, c.Reason = Conflict, "updated_at of the last synchronization is unknown"
+	case !remote.UpdatedAt.Equal(doc.UpdatedAt.Time):
+		c.Action, c.Reason = Conflict, "edited on Qiita at "+remote.UpdatedAt.String()+" since the last synchronization"
+	default:
+		c.Action = Update
+	}
+	return c, nil
+}
+
+// diff returns the fields of a document differing from its item.
+func diff(doc *Document, item *qiita.Item) []string {
+	var fields []string
+	if doc.Title != item.Title {
+		fields = append(fields, "title")
+	}
+	if !slices.EqualFunc(doc.Tags, item.Tags, func(a, b qiita.Tagging) bool {
+		return a.Name == b.Name && slices.Equal(a.Versions, b.Versions)
+	}) {
+		fields = append(fields, "tags")
+	}
+	if doc.Private != item.Private {
+		fields = append(fields, "private")
+	}
+	if doc.Coediting != item.Coediting {
+		fields = append(fields, "coediting")
+	}
+	var group string
+	if item.Group != nil {
+		group = item.Group.UrlName
+	}
+	if doc.Group != group {
+		fields = append(fields, "group")
+	}
+	if doc.OrganizationUrlName != item.OrganizationUrlName {
+		fields = append(fields, "organization_url_name")
+	}
+	if doc.Slide != item.Slide {
+		fields = append(fields, "slide")
+	}
+	if normalize(doc.Body) != normalize(item.Body) {
+		fields = append(fields, "body")
+	}
+	return fields
+}
+
+// normalize ignores line endings and trailing newlines of a body, which Qiita does not keep as written.
+func normalize(body string) string {
+	return strings.TrimRight(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+}
+
+// Posts and updates the items of a plan, and writes their ids and updated_at into the front matter of
+// the files. Conflicts are skipped. It goes on after a failure and returns the errors of all the files.
+func (s *Syncer) Apply(ctx context.Context, plan Plan) error {
+	var errs []error
+	for i := range plan {
+		if err := s.apply(ctx, &plan[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", plan[i].Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Syncer) apply(ctx context.Context, c *Change) error {
+	item := c.Local.Item()
+	var err error
+	switch c.Action {
+	case Create:
+		item.Id = ""
+		c.Remote, err = s.client.CreateItem(ctx, item)
+	case Update:
+		c.Remote, err = s.client.UpdateItem(ctx, item)
+	case Unchanged:
+		if c.Remote.UpdatedAt.Equal(c.Local.UpdatedAt.Time) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.Local.setSynced(c.Remote)
+	return s.write(c.Path, c.Local.Bytes())
+}
+
+func (s *Syncer) write(p string, b []byte) error {
+	name := filepath.Join(s.dir, filepath.FromSlash(p))
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, info.Mode().Perm())
+}
+
+// Plans and applies the synchronization of the directory, and returns the plan with the results.
+func (s *Syncer) Sync(ctx context.Context) (Plan, error) {
+	plan, err := s.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return plan, s.Apply(ctx, plan)
+}
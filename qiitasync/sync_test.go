@@ -0,0 +1,230 @@
+package qiitasync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+	"github.com/ktsujichan/qiita-sdk-go/qiitatest"
+)
+
+// A clock advancing by a second on each reading, as Qiita stamps items in seconds.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	name = filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func actions(plan Plan) string {
+	var s []string
+	for _, c := range plan {
+		s = append(s, c.Action.String()+" "+c.Path)
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	server := qiitatest.NewServer()
+	defer server.Close()
+	server.SetClock((&clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}).Now)
+	c := server.Client(server.AddUser(qiita.User{Id: "qiita"}))
+	server.AddGroup(qiita.Group{Name: "Developers", UrlName: "dev"})
+
+	dir := t.TempDir()
+	writeFile(t, dir, "context.md", article)
+	writeFile(t, dir, "drafts/errors.md", "---\ntitle: Errors in Go\ntags: [Go]\n---\nWrap errors.\n")
+	writeFile(t, dir, "README.md", "# Articles\n")
+	writeFile(t, dir, ".git/x.md", "---\ntitle: ignored\n---\n")
+
+	s := NewSyncer(c, dir)
+	plan, err := s.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(plan); got != "create context.md, create drafts/errors.md" {
+		t.Errorf("plan = %s", got)
+	}
+	if want := "create  context.md        \"Context in Go\"\ncreate  drafts/errors.md  \"Errors in Go\"\n"; plan.String() != want {
+		t.Errorf("plan =\n%s\nwant\n%s", plan, want)
+	}
+	if readFile(t, dir, "context.md") != article {
+		t.Error("the plan changed a file")
+	}
+
+	if err := s.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := ParseDocument([]byte(readFile(t, dir, "context.md")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := c.GetItem(ctx, doc.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Title != "Context in Go" || item.Body != doc.Body || !item.UpdatedAt.Equal(doc.UpdatedAt.Time) || item.Group.UrlName != "dev" {
+		t.Errorf("item %+v, document %+v", item, doc)
+	}
+
+	// Nothing changes after the synchronization.
+	if plan, err := s.Plan(ctx); err != nil || actions(plan) != "unchanged context.md, unchanged drafts/errors.md" {
+		t.Errorf("plan = %s, %v", actions(plan), err)
+	}
+
+	// A local edit updates the item.
+	writeFile(t, dir, "context.md", strings.Replace(readFile(t, dir, "context.md"), "Cancel goroutines.", "Cancel goroutines with a context.", 1))
+	plan, err = s.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(plan); got != "update context.md, unchanged drafts/errors.md" || strings.Join(plan[0].Fields, ",") != "body" {
+		t.Errorf("plan = %s %v", got, plan[0].Fields)
+	}
+	if item, _ := c.GetItem(ctx, doc.Id); !strings.Contains(item.Body, "with a context") {
+		t.Errorf("body = %q", item.Body)
+	}
+	synced := readFile(t, dir, "context.md")
+
+	// An edit on Qiita is not overwritten.
+	item, _ = c.GetItem(ctx, doc.Id)
+	item.Title = "Edited on Qiita"
+	if _, err := c.UpdateItem(ctx, *item); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "context.md", strings.Replace(synced, "title: Context in Go", "title: Edited locally", 1))
+	plan, err = s.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan[0].Action != Conflict || !strings.HasPrefix(plan[0].Reason, "edited on Qiita at 2024-06-01T") {
+		t.Errorf("plan = %s", plan)
+	}
+	if item, _ := c.GetItem(ctx, doc.Id); item.Title != "Edited on Qiita" {
+		t.Errorf("title = %q", item.Title)
+	}
+
+	// Unless forced.
+	if plan, err := s.WithForce(true).Sync(ctx); err != nil || plan[0].Action != Update {
+		t.Fatalf("plan = %s, %v", plan, err)
+	}
+	if item, _ := c.GetItem(ctx, doc.Id); item.Title != "Edited locally" {
+		t.Errorf("title = %q", item.Title)
+	}
+	s.WithForce(false)
+
+	// A deleted item is a conflict.
+	if err := c.DeleteItem(ctx, doc.Id); err != nil {
+		t.Fatal(err)
+	}
+	if plan, err := s.Plan(ctx); err != nil || plan[0].Action != Conflict || plan[0].Reason != "deleted on Qiita" {
+		t.Errorf("plan = %s, %v", plan, err)
+	}
+}
+
+// The group, the organization and the slide mode of an item are posted as the API reads them, so that
+// the item converges to its document.
+func TestSyncItemFields(t *testing.T) {
+	ctx := context.Background()
+	server := qiitatest.NewServer()
+	defer server.Close()
+	c := server.Client(server.AddUser(qiita.User{Id: "qiita"}))
+	server.AddGroup(qiita.Group{Name: "Developers", UrlName: "dev"})
+	server.AddGroup(qiita.Group{Name: "Designers", UrlName: "design"})
+
+	dir := t.TempDir()
+	const front = "---\ntitle: Slides\ntags: [Go]\ngroup: dev\norganization_url_name: increments\nslide: true\n"
+	writeFile(t, dir, "slides.md", front+"---\n# Slides\n")
+	s := NewSyncer(c, dir)
+	plan, err := s.Sync(ctx)
+	if err != nil || actions(plan) != "create slides.md" {
+		t.Fatalf("plan = %s, %v", actions(plan), err)
+	}
+	item, err := c.GetItem(ctx, plan[0].Remote.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Group == nil || item.Group.UrlName != "dev" || item.OrganizationUrlName != "increments" || !item.Slide {
+		t.Errorf("item = %+v", item)
+	}
+	if plan, err := s.Plan(ctx); err != nil || actions(plan) != "unchanged slides.md" {
+		t.Errorf("plan after the synchronization = %s, %v", actions(plan), err)
+	}
+
+	// Moving the item to another group converges too.
+	writeFile(t, dir, "slides.md", strings.Replace(readFile(t, dir, "slides.md"), "group: dev", "group: design", 1))
+	if plan, err := s.Sync(ctx); err != nil || actions(plan) != "update slides.md" || strings.Join(plan[0].Fields, ",") != "group" {
+		t.Errorf("plan = %s %v, %v", actions(plan), plan[0].Fields, err)
+	}
+	if plan, err := s.Plan(ctx); err != nil || actions(plan) != "unchanged slides.md" {
+		t.Errorf("plan after moving the item = %s, %v", actions(plan), err)
+	}
+
+	// A group which does not exist is an error, rather than an item posted outside of it.
+	writeFile(t, dir, "unknown.md", "---\ntitle: Unknown\ntags: [Go]\ngroup: unknown\n---\nbody\n")
+	if _, err := s.Sync(ctx); err == nil || !strings.HasPrefix(err.Error(), "unknown.md: qiita: POST /api/v2/items: 404") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestSyncErrors(t *testing.T) {
+	ctx := context.Background()
+	server := qiitatest.NewServer()
+	defer server.Close()
+	c := server.Client(server.AddUser(qiita.User{Id: "qiita"}))
+
+	dir := t.TempDir()
+	writeFile(t, dir, "a.md", "---\ntitle: a\nid: c686397e4a0f4f11683d\n---\n")
+	writeFile(t, dir, "b.md", "---\ntitle: b\nid: c686397e4a0f4f11683d\n---\n")
+	if _, err := NewSyncer(c, dir).Plan(ctx); err == nil || err.Error() != "b.md: id c686397e4a0f4f11683d is also in a.md" {
+		t.Errorf("err = %v", err)
+	}
+
+	writeFile(t, dir, "b.md", "---\ntitle: [b\n---\n")
+	if _, err := NewSyncer(c, dir).Plan(ctx); err == nil || !strings.HasPrefix(err.Error(), "b.md: front matter line 1") {
+		t.Errorf("err = %v", err)
+	}
+
+	// Failures of some files do not stop the others.
+	os.Remove(filepath.Join(dir, "a.md"))
+	writeFile(t, dir, "b.md", "---\ntitle: no tags\n---\nbody\n")
+	writeFile(t, dir, "c.md", "---\ntitle: c\ntags: [Go]\n---\nbody\n")
+	plan, err := NewSyncer(c, dir).Sync(ctx)
+	if err == nil || !strings.HasPrefix(err.Error(), "b.md: qiita: POST /api/v2/items: 400") {
+		t.Errorf("err = %v", err)
+	}
+	if plan[1].Remote == nil || !strings.Contains(readFile(t, dir, "c.md"), "id: "+string(plan[1].Remote.Id)) {
+		t.Errorf("c.md =\n%s", readFile(t, dir, "c.md"))
+	}
+}
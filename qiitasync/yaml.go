@@ -0,0 +1,263 @@
+package qiitasync
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A minimal parser of the YAML used in front matter: block mappings and sequences of plain, single-quoted
+// and double-quoted scalars, flow sequences such as [Go, Docker] and comments. Every scalar is parsed as
+// a string, so that versions like 1.10 are kept as written, and null as nil. Anchors, tags, flow mappings
+// and block scalars are not supported.
+type yamlParser struct {
+	lines []yamlLine
+	i     int
+}
+
+// A line other than blank lines and comments.
+type yamlLine struct {
+	no     int
+	indent int
+	text   string
+}
+
+func (l yamlLine) errorf(format string, args ...any) error {
+	return fmt.Errorf("front matter line %d: %s", l.no, fmt.Sprintf(format, args...))
+}
+
+func parseYAML(src string) (map[string]any, error) {
+	p := &yamlParser{}
+	for i, text := range strings.Split(src, "\n") {
+		text = strings.TrimRight(text, " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		l := yamlLine{no: i + 1, indent: len(text) - len(trimmed), text: trimmed}
+		if trimmed[0] == '\t' {
+			return nil, l.errorf("tabs are not allowed in indentation")
+		}
+		p.lines = append(p.lines, l)
+	}
+	if len(p.lines) == 0 {
+		return map[string]any{}, nil
+	}
+	m, err := p.mapping(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.i < len(p.lines) {
+		return nil, p.lines[p.i].errorf("unexpected indentation")
+	}
+	return m, nil
+}
+
+func isEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// node parses a collection on the lines more indented than parent, or returns nil if there is none.
+func (p *yamlParser) node(parent int) (any, error) {
+	if p.i >= len(p.lines) || p.lines[p.i].indent <= parent {
+		return nil, nil
+	}
+	l := p.lines[p.i]
+	if isEntry(l.text) {
+		return p.sequence(l.indent)
+	}
+	return p.mapping(l.indent)
+}
+
+func (p *yamlParser) mapping(indent int) (map[string]any, error) {
+	m := map[string]any{}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent {
+		l := p.lines[p.i]
+		if isEntry(l.text) {
+			return nil, l.errorf("unexpected sequence entry")
+		}
+		key, rest, ok, err := cutKey(l.text)
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+		if !ok {
+			return nil, l.errorf("want key: value")
+		}
+		if _, dup := m[key]; dup {
+			return nil, l.errorf("duplicate key %q", key)
+		}
+		p.i++
+		var v any
+		switch {
+		case rest != "":
+			v, err = scalarOrFlow(l, rest)
+		case p.i < len(p.lines) && p.lines[p.i].indent == indent && isEntry(p.lines[p.i].text):
+			// A sequence may be as indented as its key.
+			v, err = p.sequence(indent)
+		default:
+			v, err = p.node(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	if p.i < len(p.lines) && p.lines[p.i].indent > indent {
+		return nil, p.lines[p.i].errorf("unexpected indentation")
+	}
+	return m, nil
+}
+
+func (p *yamlParser) sequence(indent int) ([]any, error) {
+	s := []any{}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && isEntry(p.lines[p.i].text) {
+		l := p.lines[p.i]
+		rest := strings.TrimLeft(l.text[1:], " ")
+		var v any
+		var err error
+		if rest == "" {
+			p.i++
+			v, err = p.node(indent)
+		} else if _, _, ok, _ := cutKey(rest); ok {
+			// A mapping starting on the line of the entry, e.g. "- name: Go".
+			p.lines[p.i] = yamlLine{no: l.no, indent: indent + len(l.text) - len(rest), text: rest}
+			v, err = p.mapping(p.lines[p.i].indent)
+		} else {
+			p.i++
+			v, err = scalarOrFlow(l, rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, v)
+	}
+	if p.i < len(p.lines) && p.lines[p.i].indent > indent {
+		return nil, p.lines[p.i].errorf("unexpected indentation")
+	}
+	return s, nil
+}
+
+// cutKey splits "key: value" into the key and the value, and reports whether text is a mapping entry.
+func cutKey(text string) (key, rest string, ok bool, err error) {
+	if text[0] == '"' || text[0] == '\'' {
+		key, rest, err = quoted(text)
+		if err != nil {
+			return "", "", false, err
+		}
+		if rest == ":" || strings.HasPrefix(rest, ": ") {
+			return key, strings.TrimSpace(rest[1:]), true, nil
+		}
+		return "", "", false, nil
+	}
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return "", "", false, nil
+	}
+	if i := strings.Index(text, ": "); i >= 0 && !strings.Contains(text[:i], " #") {
+		return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+2:]), true, nil
+	}
+	if strings.HasSuffix(text, ":") {
+		return strings.TrimSpace(text[:len(text)-1]), "", true, nil
+	}
+	return "", "", false, nil
+}
+
+// scalarOrFlow parses the value following a key or a dash on its line.
+func scalarOrFlow(l yamlLine, s string) (any, error) {
+	switch s[0] {
+	case '[':
+		return flowSequence(l, s)
+	case '{':
+		if stripComment(s) == "{}" {
+			return map[string]any{}, nil
+		}
+		return nil, l.errorf("flow mappings are not supported")
+	case '|', '>':
+		return nil, l.errorf("block scalars are not supported")
+	case '&', '*', '!':
+		return nil, l.errorf("anchors, aliases and tags are not supported")
+	case '"', '\'':
+		v, rest, err := quoted(s)
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, l.errorf("unexpected %q after a quoted scalar", rest)
+		}
+		return v, nil
+	}
+	return plain(stripComment(s)), nil
+}
+
+func flowSequence(l yamlLine, s string) ([]any, error) {
+	items := []any{}
+	s = strings.TrimSpace(s[1:])
+	for {
+		if s == "" {
+			return nil, l.errorf("unterminated flow sequence")
+		}
+		if s[0] == ']' {
+			if rest := strings.TrimSpace(s[1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, l.errorf("unexpected %q after a flow sequence", rest)
+			}
+			return items, nil
+		}
+		if s[0] == '"' || s[0] == '\'' {
+			v, rest, err := quoted(s)
+			if err != nil {
+				return nil, l.errorf("%v", err)
+			}
+			items, s = append(items, v), rest
+		} else {
+			i := strings.IndexAny(s, ",]")
+			if i < 0 {
+				return nil, l.errorf("unterminated flow sequence")
+			}
+			items, s = append(items, plain(s[:i])), s[i:]
+		}
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(s, ",") {
+			s = strings.TrimSpace(s[1:])
+		} else if !strings.HasPrefix(s, "]") {
+			return nil, l.errorf("want , or ] in a flow sequence")
+		}
+	}
+}
+
+// quoted parses the quoted scalar at the start of s, and returns it and the rest of s.
+func quoted(s string) (string, string, error) {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q && q == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == q:
+			rest := strings.TrimSpace(s[i+1:])
+			if q == '\'' {
+				return strings.ReplaceAll(s[1:i], "''", "'"), rest, nil
+			}
+			v, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid double-quoted scalar %s", s[:i+1])
+			}
+			return v, rest, nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quoted scalar %s", s)
+}
+
+func stripComment(s string) string {
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func plain(s string) any {
+	switch s = strings.TrimSpace(s); s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	}
+	return s
+}
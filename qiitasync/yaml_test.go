@@ -0,0 +1,90 @@
+package qiitasync
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ktsujichan/qiita-sdk-go/internal/yamlscalar"
+)
+
+func TestParseYAML(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want map[string]any
+	}{
+		{"", map[string]any{}},
+		{"# comment\n\n", map[string]any{}},
+		{"title: Context in Go\nprivate: false", map[string]any{"title": "Context in Go", "private": "false"}},
+		{"title: 'It''s # not a comment' # comment\nid: \"c686\\u0033\"", map[string]any{"title": "It's # not a comment", "id": "c6863"}},
+		{"title: Go: tips\nurl: https://qiita.com", map[string]any{"title": "Go: tips", "url": "https://qiita.com"}},
+		{"id:\nupdated_at: ~\ngroup: null", map[string]any{"id": nil, "updated_at": nil, "group": nil}},
+		{"tags: [Go, 'C#', \"a, b\", ]", map[string]any{"tags": []any{"Go", "C#", "a, b"}}},
+		{"tags: []\nmeta: {}", map[string]any{"tags": []any{}, "meta": map[string]any{}}},
+		{"tags:\n- Go\n- Docker\ntitle: x", map[string]any{"tags": []any{"Go", "Docker"}, "title": "x"}},
+		{
+			"tags:\n  - name: Go\n    versions:\n      - 1.10\n      - '1.22'\n  - name: Docker\n    versions: [24.0]\n  - Ruby\n",
+			map[string]any{"tags": []any{
+				map[string]any{"name": "Go", "versions": []any{"1.10", "1.22"}},
+				map[string]any{"name": "Docker", "versions": []any{"24.0"}},
+				"Ruby",
+			}},
+		},
+		{"a:\n  b:\n    c: d\n  e: f", map[string]any{"a": map[string]any{"b": map[string]any{"c": "d"}, "e": "f"}}},
+		{"list:\n  -\n    - nested\n  - x", map[string]any{"list": []any{[]any{"nested"}, "x"}}},
+	} {
+		got, err := parseYAML(tt.src)
+		if err != nil {
+			t.Errorf("%q: %v", tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q = %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseYAMLError(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want string
+	}{
+		{"title", "line 1: want key: value"},
+		{"a: 1\n  b: 2", "line 2: unexpected indentation"},
+		{"a: 1\na: 2", `line 2: duplicate key "a"`},
+		{"body: |\n  text", "line 1: block scalars are not supported"},
+		{"tags: [Go", "line 1: unterminated flow sequence"},
+		{"tags: [Go] x", `line 1: unexpected "x" after a flow sequence`},
+		{"title: 'open", "unterminated quoted scalar"},
+		{"title: \"x\" y", `unexpected "y" after a quoted scalar`},
+		{"meta: {a: b}", "flow mappings are not supported"},
+		{"a: &anchor x", "anchors, aliases and tags are not supported"},
+		{"tags:\n  - Go\n  x: y", "line 3: unexpected indentation"},
+		{"a: 1\n- x", "line 2: unexpected sequence entry"},
+		{"a:\n\tb: c", "line 2: tabs are not allowed in indentation"},
+	} {
+		_, err := parseYAML(tt.src)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: err = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+// Strings formatted for front matter are parsed back as the same strings.
+func TestFormattedScalars(t *testing.T) {
+	for _, s := range []string{
+		"c686397e4a0f4f11683d", "12345678901234567890", "1e10", "true", "null", "~", "",
+		"2024-06-01T12:00:00+09:00", "it's", "C#", "C #", "a: b", "a:", "- a", "[Go]", "{}", "'quoted'",
+		`"quoted"`, " padded ", "line\nbreak", "tab\t", "|", ">", "&anchor", "*alias", "!tag", "%", "@", "`",
+	} {
+		src := "title: " + yamlscalar.String(s) + "\ntags:\n  - " + yamlscalar.String(s) + "\n"
+		m, err := parseYAML(src)
+		if err != nil {
+			t.Errorf("%q: %v", src, err)
+			continue
+		}
+		if tags, _ := m["tags"].([]any); m["title"] != s || len(tags) != 1 || tags[0] != s {
+			t.Errorf("%q parsed as %#v, want %q", src, m, s)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+func (s *Server) comment(id qiita.CommentId) *comment {
+	for _, c := range s.comments {
+		if c.Id == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// renderComment returns c with the current state of its author.
+func (s *Server) renderComment(c *comment) qiita.Comment {
+	rendered := c.Comment
+	rendered.User = s.renderUserId(c.userId)
+	return rendered
+}
+
+// listedComments returns the comments matching match in newest order.
+func (s *Server) listedComments(match func(c *comment) bool) qiita.Comments {
+	comments := qiita.Comments{}
+	for _, c := range slices.Backward(s.comments) {
+		if match(c) {
+			comments = append(comments, s.renderComment(c))
+		}
+	}
+	return comments
+}
+
+// newComment creates a comment of me from the body of r, or responds with an error.
+func (s *Server) newComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) *comment {
+	var posted qiita.Comment
+	if !decode(w, r, &posted) {
+		return nil
+	}
+	if posted.Body == "" {
+		badRequest(w, "Body is empty")
+		return nil
+	}
+	now := s.timestamp()
+	c := &comment{
+		Comment: qiita.Comment{
+			Body:         posted.Body,
+			CreatedAt:    now,
+			Id:           qiita.CommentId(randomHex(20)),
+			RenderedBody: renderBody(posted.Body),
+			UpdatedAt:    now,
+		},
+		userId: me,
+	}
+	s.comments = append(s.comments, c)
+	return c
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.listedComments(func(c *comment) bool {
+		return c.itemId == item.Id
+	}))
+}
+
+func (s *Server) postComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	if c := s.newComment(w, r, me); c != nil {
+		c.itemId = item.Id
+		writeJSON(w, http.StatusCreated, s.renderComment(c))
+	}
+}
+
+func (s *Server) listProjectComments(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	project := s.project(r.PathValue("project_id"))
+	if project == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.listedComments(func(c *comment) bool {
+		return c.projectId == project.Id
+	}))
+}
+
+func (s *Server) postProjectComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	project := s.project(r.PathValue("project_id"))
+	if project == nil {
+		notFound(w)
+		return
+	}
+	if c := s.newComment(w, r, me); c != nil {
+		c.projectId = project.Id
+		writeJSON(w, http.StatusCreated, s.renderComment(c))
+	}
+}
+
+func (s *Server) getComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	c := s.comment(qiita.CommentId(r.PathValue("comment_id")))
+	if c == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderComment(c))
+}
+
+// ownComment returns the comment of the request if it is written by me, or responds with an error.
+func (s *Server) ownComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) *comment {
+	c := s.comment(qiita.CommentId(r.PathValue("comment_id")))
+	if c == nil {
+		notFound(w)
+		return nil
+	}
+	if c.userId != me {
+		forbidden(w)
+		return nil
+	}
+	return c
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	c := s.ownComment(w, r, me)
+	if c == nil {
+		return
+	}
+	var update qiita.Comment
+	if !decode(w, r, &update) {
+		return
+	}
+	if update.Body == "" {
+		badRequest(w, "Body is empty")
+		return
+	}
+	c.Body = update.Body
+	c.RenderedBody = renderBody(update.Body)
+	c.UpdatedAt = s.timestamp()
+	writeJSON(w, http.StatusOK, s.renderComment(c))
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	c := s.ownComment(w, r, me)
+	if c == nil {
+		return
+	}
+	s.comments = slices.DeleteFunc(s.comments, func(other *comment) bool { return other == c })
+	s.deleteReactions("comments/" + string(c.Id))
+	noContent(w)
+}
@@ -0,0 +1,54 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Registers a group of Qiita:Team, to which items can be posted by its url name.
+func (s *Server) AddGroup(group qiita.Group) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if group.UrlName == "" {
+		panic("qiitatest: group url name is empty")
+	}
+	if s.group(group.UrlName) == nil {
+		group.Id = uint(len(s.groups) + 1)
+		group.CreatedAt = s.timestamp()
+		group.UpdatedAt = group.CreatedAt
+		s.groups = append(s.groups, &group)
+	}
+}
+
+func (s *Server) group(urlName string) *qiita.Group {
+	for _, g := range s.groups {
+		if g.UrlName == urlName {
+			return g
+		}
+	}
+	return nil
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	groups := []qiita.Group{}
+	for _, g := range slices.Backward(s.groups) {
+		groups = append(groups, *g)
+	}
+	writePage(w, r, groups)
+}
+
+// itemGroup returns the group named by the group_url_name of an item posted or updated, or responds
+// 404 Not Found if there is no such group.
+func (s *Server) itemGroup(w http.ResponseWriter, urlName string) (*qiita.Group, bool) {
+	if urlName == "" {
+		return nil, true
+	}
+	g := s.group(urlName)
+	if g == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Group not found")
+		return nil, false
+	}
+	return g, true
+}
@@ -0,0 +1,431 @@
+package qiitatest
+
+import (
+	"html"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Qiita API v2 requires from one to five tags on an item.
+const maxTags = 5
+
+func (s *Server) item(id qiita.ItemId) *qiita.Item {
+	for _, item := range s.items {
+		if item.Id == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// renderItem returns item with its counters and the current state of its author.
+func (s *Server) renderItem(item *qiita.Item) qiita.Item {
+	rendered := *item
+	rendered.Tags = slices.Clone(item.Tags)
+	u := s.renderUserId(item.User.Id)
+	rendered.User = &u
+	rendered.CommentsCount, rendered.LikesCount, rendered.StocksCount, rendered.ReactionsCount = 0, 0, 0, 0
+	for _, c := range s.comments {
+		if c.itemId == item.Id {
+			rendered.CommentsCount++
+		}
+	}
+	for _, l := range s.likes {
+		if l.itemId == item.Id {
+			rendered.LikesCount++
+		}
+	}
+	for _, st := range s.stocks {
+		if st.itemId == item.Id {
+			rendered.StocksCount++
+		}
+	}
+	for _, re := range s.reactions {
+		if re.target == "items/"+string(item.Id) {
+			rendered.ReactionsCount++
+		}
+	}
+	return rendered
+}
+
+// listed returns the items matching match in newest order, excluding private ones.
+func (s *Server) listed(match func(item *qiita.Item) bool) []qiita.Item {
+	items := []qiita.Item{}
+	for _, item := range slices.Backward(s.items) {
+		if !item.Private && match(item) {
+			items = append(items, s.renderItem(item))
+		}
+	}
+	return items
+}
+
+// validateItem returns the reason why item cannot be saved, or "" if it can.
+func validateItem(item qiita.Item) string {
+	switch {
+	case item.Title == "":
+		return "Title is empty"
+	case item.Body == "":
+		return "Body is empty"
+	case len(item.Tags) == 0 || len(item.Tags) > maxTags:
+		return "Tags must be from 1 to 5"
+	}
+	for _, t := range item.Tags {
+		if t.Name == "" {
+			return "Tag name is empty"
+		}
+	}
+	return ""
+}
+
+func renderBody(body string) string {
+	return "<p>" + html.EscapeString(body) + "</p>\n"
+}
+
+func hasTag(item *qiita.Item, name string) bool {
+	return slices.ContainsFunc(item.Tags, func(t qiita.Tagging) bool {
+		return strings.EqualFold(t.Name, name)
+	})
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// matchQuery reports whether item matches all terms of a search query.
+func matchQuery(item *qiita.Item, query string) bool {
+	for _, term := range strings.Fields(query) {
+		key, value, ok := strings.Cut(term, ":")
+		switch {
+		case ok && key == "tag":
+			if !hasTag(item, value) {
+				return false
+			}
+		case ok && key == "user":
+			if string(item.User.Id) != value {
+				return false
+			}
+		case ok && key == "title":
+			if !containsFold(item.Title, value) {
+				return false
+			}
+		case ok && key == "body":
+			if !containsFold(item.Body, value) {
+				return false
+			}
+		default:
+			if !containsFold(item.Title, term) && !containsFold(item.Body, term) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	query := r.URL.Query().Get("query")
+	writePage(w, r, s.listed(func(item *qiita.Item) bool {
+		return matchQuery(item, query)
+	}))
+}
+
+func (s *Server) listAuthenticatedUserItems(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	items := []qiita.Item{}
+	for _, item := range slices.Backward(s.items) {
+		if item.User.Id == me {
+			items = append(items, s.renderItem(item))
+		}
+	}
+	writePage(w, r, items)
+}
+
+func (s *Server) listTaggedItems(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	tag := s.tag(qiita.TagId(r.PathValue("tag_id")))
+	if tag == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.listed(func(item *qiita.Item) bool {
+		return hasTag(item, string(tag.Id))
+	}))
+}
+
+func (s *Server) listUserItems(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.listed(func(item *qiita.Item) bool {
+		return item.User.Id == u.Id
+	}))
+}
+
+func (s *Server) listUserStocks(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	items := []qiita.Item{}
+	for _, st := range slices.Backward(s.stocks) {
+		if st.userId == u.Id {
+			items = append(items, s.renderItem(s.item(st.itemId)))
+		}
+	}
+	writePage(w, r, items)
+}
+
+func (s *Server) createItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	var item qiita.Item
+	if !decode(w, r, &item) {
+		return
+	}
+	if reason := validateItem(item); reason != "" {
+		badRequest(w, reason)
+		return
+	}
+	group, ok := s.itemGroup(w, item.GroupUrlName)
+	if !ok {
+		return
+	}
+	now := s.timestamp()
+	created := &qiita.Item{
+		Body:                item.Body,
+		Coediting:           item.Coediting,
+		CreatedAt:           now,
+		Group:               group,
+		Id:                  qiita.ItemId(randomHex(20)),
+		OrganizationUrlName: item.OrganizationUrlName,
+		Private:             item.Private,
+		RenderedBody:        renderBody(item.Body),
+		Slide:               item.Slide,
+		Tags:                item.Tags,
+		Title:               item.Title,
+		UpdatedAt:           now,
+		User:                s.user(me),
+	}
+	created.Url = s.URL + "/" + string(me) + "/items/" + string(created.Id)
+	s.ensureTags(item.Tags)
+	s.items = append(s.items, created)
+	writeJSON(w, http.StatusCreated, s.renderItem(created))
+}
+
+func (s *Server) getItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderItem(item))
+}
+
+// ownItem returns the item of the request if it is written by me, or responds with an error.
+func (s *Server) ownItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) *qiita.Item {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return nil
+	}
+	if item.User.Id != me {
+		forbidden(w)
+		return nil
+	}
+	return item
+}
+
+func (s *Server) updateItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.ownItem(w, r, me)
+	if item == nil {
+		return
+	}
+	var update qiita.Item
+	if !decode(w, r, &update) {
+		return
+	}
+	if reason := validateItem(update); reason != "" {
+		badRequest(w, reason)
+		return
+	}
+	// An item stays in its group unless another one is given.
+	if update.GroupUrlName != "" {
+		group, ok := s.itemGroup(w, update.GroupUrlName)
+		if !ok {
+			return
+		}
+		item.Group = group
+	}
+	item.Title = update.Title
+	item.Body = update.Body
+	item.RenderedBody = renderBody(update.Body)
+	item.Coediting = update.Coediting
+	item.OrganizationUrlName = update.OrganizationUrlName
+	item.Private = update.Private
+	item.Slide = update.Slide
+	item.Tags = update.Tags
+	item.UpdatedAt = s.timestamp()
+	s.ensureTags(update.Tags)
+	writeJSON(w, http.StatusOK, s.renderItem(item))
+}
+
+func (s *Server) deleteItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.ownItem(w, r, me)
+	if item == nil {
+		return
+	}
+	s.items = slices.DeleteFunc(s.items, func(i *qiita.Item) bool { return i == item })
+	s.comments = slices.DeleteFunc(s.comments, func(c *comment) bool {
+		if c.itemId != item.Id {
+			return false
+		}
+		s.deleteReactions("comments/" + string(c.Id))
+		return true
+	})
+	s.stocks = slices.DeleteFunc(s.stocks, func(st stock) bool { return st.itemId == item.Id })
+	s.likes = slices.DeleteFunc(s.likes, func(l like) bool { return l.itemId == item.Id })
+	s.deleteReactions("items/" + string(item.Id))
+	noContent(w)
+}
+
+func (s *Server) listStockers(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	users := []qiita.User{}
+	for _, st := range slices.Backward(s.stocks) {
+		if st.itemId == item.Id {
+			users = append(users, s.renderUserId(st.userId))
+		}
+	}
+	writePage(w, r, users)
+}
+
+func (s *Server) getStock(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	if !slices.Contains(s.stocks, stock{qiita.ItemId(r.PathValue("item_id")), me}) {
+		notFound(w)
+		return
+	}
+	noContent(w)
+}
+
+func (s *Server) stockItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	if st := (stock{item.Id, me}); !slices.Contains(s.stocks, st) {
+		s.stocks = append(s.stocks, st)
+	}
+	noContent(w)
+}
+
+func (s *Server) unstockItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	i := slices.Index(s.stocks, stock{qiita.ItemId(r.PathValue("item_id")), me})
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	s.stocks = slices.Delete(s.stocks, i, i+1)
+	noContent(w)
+}
+
+func (s *Server) likeIndex(itemId qiita.ItemId, userId qiita.UserId) int {
+	return slices.IndexFunc(s.likes, func(l like) bool {
+		return l.itemId == itemId && l.userId == userId
+	})
+}
+
+func (s *Server) listItemLikes(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	likes := qiita.Likes{}
+	for _, l := range slices.Backward(s.likes) {
+		if l.itemId == item.Id {
+			likes = append(likes, qiita.Like{CreatedAt: l.createdAt, User: s.renderUserId(l.userId)})
+		}
+	}
+	writeJSON(w, http.StatusOK, likes)
+}
+
+func (s *Server) getLike(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	if s.likeIndex(qiita.ItemId(r.PathValue("item_id")), me) < 0 {
+		notFound(w)
+		return
+	}
+	noContent(w)
+}
+
+func (s *Server) likeItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.item(qiita.ItemId(r.PathValue("item_id")))
+	if item == nil {
+		notFound(w)
+		return
+	}
+	if s.likeIndex(item.Id, me) < 0 {
+		s.likes = append(s.likes, like{item.Id, me, s.timestamp()})
+	}
+	noContent(w)
+}
+
+func (s *Server) unlikeItem(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	i := s.likeIndex(qiita.ItemId(r.PathValue("item_id")), me)
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	s.likes = slices.Delete(s.likes, i, i+1)
+	noContent(w)
+}
+
+func (s *Server) addItemTagging(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.ownItem(w, r, me)
+	if item == nil {
+		return
+	}
+	var tagging qiita.Tagging
+	if !decode(w, r, &tagging) {
+		return
+	}
+	switch {
+	case tagging.Name == "":
+		badRequest(w, "Tag name is empty")
+		return
+	case hasTag(item, tagging.Name):
+		forbidden(w)
+		return
+	case len(item.Tags) >= maxTags:
+		badRequest(w, "Tags must be from 1 to 5")
+		return
+	}
+	item.Tags = append(item.Tags, tagging)
+	s.ensureTags(qiita.Taggings{tagging})
+	writeJSON(w, http.StatusCreated, tagging)
+}
+
+func (s *Server) deleteItemTagging(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	item := s.ownItem(w, r, me)
+	if item == nil {
+		return
+	}
+	name := r.PathValue("tagging_id")
+	if !hasTag(item, name) {
+		notFound(w)
+		return
+	}
+	if len(item.Tags) == 1 {
+		badRequest(w, "Tags must be from 1 to 5")
+		return
+	}
+	item.Tags = slices.DeleteFunc(item.Tags, func(t qiita.Tagging) bool {
+		return strings.EqualFold(t.Name, name)
+	})
+	noContent(w)
+}
@@ -0,0 +1,106 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// project returns the project of the id in a path, or nil if it is malformed or unknown.
+func (s *Server) project(id string) *qiita.Project {
+	n, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return nil
+	}
+	for _, p := range s.projects {
+		if p.Id == qiita.ProjectId(n) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	projects := []qiita.Project{}
+	for _, p := range slices.Backward(s.projects) {
+		projects = append(projects, *p)
+	}
+	writePage(w, r, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	var project qiita.Project
+	if !decode(w, r, &project) {
+		return
+	}
+	if project.Name == "" {
+		badRequest(w, "Name is empty")
+		return
+	}
+	s.lastProjectId++
+	now := s.timestamp()
+	created := &qiita.Project{
+		Archived:     project.Archived,
+		Body:         project.Body,
+		CreatedAt:    now,
+		Id:           s.lastProjectId,
+		Name:         project.Name,
+		RenderedBody: renderBody(project.Body),
+		Tags:         project.Tags,
+		UpdatedAt:    now,
+	}
+	s.projects = append(s.projects, created)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	project := s.project(r.PathValue("project_id"))
+	if project == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	project := s.project(r.PathValue("project_id"))
+	if project == nil {
+		notFound(w)
+		return
+	}
+	var update qiita.Project
+	if !decode(w, r, &update) {
+		return
+	}
+	if update.Name == "" {
+		badRequest(w, "Name is empty")
+		return
+	}
+	project.Archived = update.Archived
+	project.Body = update.Body
+	project.Name = update.Name
+	project.RenderedBody = renderBody(update.Body)
+	project.Tags = update.Tags
+	project.UpdatedAt = s.timestamp()
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	project := s.project(r.PathValue("project_id"))
+	if project == nil {
+		notFound(w)
+		return
+	}
+	s.projects = slices.DeleteFunc(s.projects, func(p *qiita.Project) bool { return p == project })
+	s.comments = slices.DeleteFunc(s.comments, func(c *comment) bool {
+		if c.projectId != project.Id {
+			return false
+		}
+		s.deleteReactions("comments/" + string(c.Id))
+		return true
+	})
+	s.deleteReactions("projects/" + project.Id.String())
+	noContent(w)
+}
@@ -0,0 +1,97 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// exists reports whether the item, comment or project of id exists, for kind "items", "comments"
+// or "projects".
+func (s *Server) exists(kind, id string) bool {
+	switch kind {
+	case "items":
+		return s.item(qiita.ItemId(id)) != nil
+	case "comments":
+		return s.comment(qiita.CommentId(id)) != nil
+	case "projects":
+		return s.project(id) != nil
+	}
+	return false
+}
+
+func (s *Server) renderReaction(re reaction) qiita.Reaction {
+	return qiita.Reaction{
+		CreatedAt: re.createdAt,
+		Name:      re.name,
+		User:      s.renderUserId(re.userId),
+	}
+}
+
+func (s *Server) deleteReactions(target string) {
+	s.reactions = slices.DeleteFunc(s.reactions, func(re reaction) bool { return re.target == target })
+}
+
+// listReactions handles GET /api/v2/<kind>/:id/reactions, where the id is the path value of param.
+func (s *Server) listReactions(kind, param string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+		id := r.PathValue(param)
+		if !s.exists(kind, id) {
+			notFound(w)
+			return
+		}
+		reactions := qiita.Reactions{}
+		for _, re := range slices.Backward(s.reactions) {
+			if re.target == kind+"/"+id {
+				reactions = append(reactions, s.renderReaction(re))
+			}
+		}
+		writeJSON(w, http.StatusOK, reactions)
+	}
+}
+
+// addReaction handles POST /api/v2/<kind>/:id/reactions. A user can react with an emoji only once.
+func (s *Server) addReaction(kind, param string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+		id := r.PathValue(param)
+		if !s.exists(kind, id) {
+			notFound(w)
+			return
+		}
+		var posted qiita.Reaction
+		if !decode(w, r, &posted) {
+			return
+		}
+		if posted.Name == "" {
+			badRequest(w, "Name is empty")
+			return
+		}
+		if s.reactionIndex(kind+"/"+id, me, posted.Name) >= 0 {
+			forbidden(w)
+			return
+		}
+		re := reaction{target: kind + "/" + id, userId: me, name: posted.Name, createdAt: s.timestamp()}
+		s.reactions = append(s.reactions, re)
+		writeJSON(w, http.StatusCreated, s.renderReaction(re))
+	}
+}
+
+// deleteReaction handles DELETE /api/v2/<kind>/:id/reactions/:reaction_name.
+func (s *Server) deleteReaction(kind, param string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+		i := s.reactionIndex(kind+"/"+r.PathValue(param), me, r.PathValue("reaction_name"))
+		if i < 0 {
+			notFound(w)
+			return
+		}
+		s.reactions = slices.Delete(s.reactions, i, i+1)
+		noContent(w)
+	}
+}
+
+func (s *Server) reactionIndex(target string, userId qiita.UserId, name string) int {
+	return slices.IndexFunc(s.reactions, func(re reaction) bool {
+		return re.target == target && re.userId == userId && re.name == name
+	})
+}
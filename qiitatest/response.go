@@ -0,0 +1,107 @@
+package qiitatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Qiita API v2 rejects page numbers greater than 100 and per_page values greater than 100.
+const (
+	maxPage        = 100
+	maxPerPage     = 100
+	defaultPerPage = 20
+)
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error body of Qiita API v2, such as {"message":"Not found","type":"not_found"}.
+func writeError(w http.ResponseWriter, status int, typ, message string) {
+	writeJSON(w, status, map[string]string{"message": message, "type": typ})
+}
+
+func badRequest(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, "bad_request", message)
+}
+
+func forbidden(w http.ResponseWriter) {
+	writeError(w, http.StatusForbidden, "forbidden", "Forbidden")
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "Not found")
+}
+
+func noContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decode reads the JSON body of r into v, and responds with 400 if it is malformed.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		badRequest(w, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writePage writes the page of all requested by the page and per_page parameters of r, along with
+// the Link and Total-Count headers.
+func writePage[T any](w http.ResponseWriter, r *http.Request, all []T) {
+	page, err := intParam(r, "page", 1, maxPage)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	perPage, err := intParam(r, "per_page", defaultPerPage, maxPerPage)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	last := max((len(all)+perPage-1)/perPage, 1)
+	links := []string{link(r, 1, "first")}
+	if page > 1 {
+		links = append(links, link(r, page-1, "prev"))
+	}
+	if page < min(last, maxPage) {
+		links = append(links, link(r, page+1, "next"))
+	}
+	links = append(links, link(r, min(last, maxPage), "last"))
+	w.Header().Set("Link", strings.Join(links, ", "))
+	w.Header().Set("Total-Count", strconv.Itoa(len(all)))
+
+	start := min((page-1)*perPage, len(all))
+	end := min(start+perPage, len(all))
+	writeJSON(w, http.StatusOK, append([]T{}, all[start:end]...))
+}
+
+func intParam(r *http.Request, name string, def, limit int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > limit {
+		return 0, fmt.Errorf("%s must be between 1 and %d", name, limit)
+	}
+	return n, nil
+}
+
+func link(r *http.Request, page int, rel string) string {
+	u := *r.URL
+	u.Scheme, u.Host = "http", r.Host
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+}
+
+func fmtUint(n uint) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
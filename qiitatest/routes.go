@@ -0,0 +1,129 @@
+package qiitatest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A minimal router of endpoints such as "GET /api/v2/items/{item_id}". It does not depend on the
+// patterns of http.ServeMux, which are disabled for modules declaring Go older than 1.22.
+type router struct {
+	routes []route
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+func (rt *router) HandleFunc(pattern string, handler http.HandlerFunc) {
+	method, p, _ := strings.Cut(pattern, " ")
+	rt.routes = append(rt.routes, route{method, strings.Split(p, "/"), handler})
+}
+
+// ServeHTTP calls the handler of the first route matching r, with the path values of its wildcards set.
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.EscapedPath(), "/")
+	for _, route := range rt.routes {
+		if route.method != r.Method || len(route.segments) != len(segments) {
+			continue
+		}
+		values := map[string]string{}
+		for i, s := range route.segments {
+			if name, ok := strings.CutPrefix(s, "{"); ok {
+				v, err := url.PathUnescape(segments[i])
+				if err != nil || v == "" {
+					break
+				}
+				values[strings.TrimSuffix(name, "}")] = v
+			} else if s != segments[i] {
+				break
+			}
+			if i == len(segments)-1 {
+				for name, v := range values {
+					r.SetPathValue(name, v)
+				}
+				route.handler(w, r)
+				return
+			}
+		}
+	}
+	notFound(w)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := &router{}
+
+	s.handle(mux, "GET /api/v2/authenticated_user", true, s.getAuthenticatedUser)
+	s.handle(mux, "GET /api/v2/authenticated_user/items", true, s.listAuthenticatedUserItems)
+
+	s.handle(mux, "GET /api/v2/items", false, s.listItems)
+	s.handle(mux, "POST /api/v2/items", true, s.createItem)
+	s.handle(mux, "GET /api/v2/items/{item_id}", false, s.getItem)
+	s.handle(mux, "PATCH /api/v2/items/{item_id}", true, s.updateItem)
+	s.handle(mux, "DELETE /api/v2/items/{item_id}", true, s.deleteItem)
+	s.handle(mux, "GET /api/v2/items/{item_id}/stock", true, s.getStock)
+	s.handle(mux, "PUT /api/v2/items/{item_id}/stock", true, s.stockItem)
+	s.handle(mux, "DELETE /api/v2/items/{item_id}/stock", true, s.unstockItem)
+	s.handle(mux, "GET /api/v2/items/{item_id}/stockers", false, s.listStockers)
+	s.handle(mux, "GET /api/v2/items/{item_id}/like", true, s.getLike)
+	s.handle(mux, "PUT /api/v2/items/{item_id}/like", true, s.likeItem)
+	s.handle(mux, "DELETE /api/v2/items/{item_id}/like", true, s.unlikeItem)
+	s.handle(mux, "GET /api/v2/items/{item_id}/likes", false, s.listItemLikes)
+	s.handle(mux, "POST /api/v2/items/{item_id}/taggings", true, s.addItemTagging)
+	s.handle(mux, "DELETE /api/v2/items/{item_id}/taggings/{tagging_id}", true, s.deleteItemTagging)
+
+	s.handle(mux, "GET /api/v2/items/{item_id}/comments", false, s.listComments)
+	s.handle(mux, "POST /api/v2/items/{item_id}/comments", true, s.postComment)
+	s.handle(mux, "GET /api/v2/comments/{comment_id}", false, s.getComment)
+	s.handle(mux, "PATCH /api/v2/comments/{comment_id}", true, s.updateComment)
+	s.handle(mux, "DELETE /api/v2/comments/{comment_id}", true, s.deleteComment)
+
+	for _, kind := range []struct{ name, param string }{
+		{"items", "item_id"},
+		{"comments", "comment_id"},
+		{"projects", "project_id"},
+	} {
+		prefix := "/api/v2/" + kind.name + "/{" + kind.param + "}/reactions"
+		s.handle(mux, "GET "+prefix, false, s.listReactions(kind.name, kind.param))
+		s.handle(mux, "POST "+prefix, true, s.addReaction(kind.name, kind.param))
+		s.handle(mux, "DELETE "+prefix+"/{reaction_name}", true, s.deleteReaction(kind.name, kind.param))
+	}
+
+	s.handle(mux, "GET /api/v2/tags", false, s.listTags)
+	s.handle(mux, "GET /api/v2/tags/{tag_id}", false, s.getTag)
+	s.handle(mux, "GET /api/v2/tags/{tag_id}/items", false, s.listTaggedItems)
+	s.handle(mux, "GET /api/v2/tags/{tag_id}/following", true, s.getTagFollowing)
+	s.handle(mux, "PUT /api/v2/tags/{tag_id}/following", true, s.followTag)
+	s.handle(mux, "DELETE /api/v2/tags/{tag_id}/following", true, s.unfollowTag)
+
+	s.handle(mux, "GET /api/v2/users", false, s.listUsers)
+	s.handle(mux, "GET /api/v2/users/{user_id}", false, s.getUser)
+	s.handle(mux, "GET /api/v2/users/{user_id}/items", false, s.listUserItems)
+	s.handle(mux, "GET /api/v2/users/{user_id}/stocks", false, s.listUserStocks)
+	s.handle(mux, "GET /api/v2/users/{user_id}/following_tags", false, s.listFollowingTags)
+	s.handle(mux, "GET /api/v2/users/{user_id}/followees", false, s.listFollowees)
+	s.handle(mux, "GET /api/v2/users/{user_id}/followers", false, s.listFollowers)
+	s.handle(mux, "GET /api/v2/users/{user_id}/following", true, s.getFollowing)
+	s.handle(mux, "PUT /api/v2/users/{user_id}/following", true, s.followUser)
+	s.handle(mux, "DELETE /api/v2/users/{user_id}/following", true, s.unfollowUser)
+
+	s.handle(mux, "GET /api/v2/groups", true, s.listGroups)
+
+	s.handle(mux, "GET /api/v2/projects", true, s.listProjects)
+	s.handle(mux, "POST /api/v2/projects", true, s.createProject)
+	s.handle(mux, "GET /api/v2/projects/{project_id}", true, s.getProject)
+	s.handle(mux, "PATCH /api/v2/projects/{project_id}", true, s.updateProject)
+	s.handle(mux, "DELETE /api/v2/projects/{project_id}", true, s.deleteProject)
+	s.handle(mux, "GET /api/v2/projects/{project_id}/comments", true, s.listProjectComments)
+	s.handle(mux, "POST /api/v2/projects/{project_id}/comments", true, s.postProjectComment)
+
+	s.handle(mux, "GET /api/v2/templates", true, s.listTemplates)
+	s.handle(mux, "POST /api/v2/templates", true, s.createTemplate)
+	s.handle(mux, "GET /api/v2/templates/{template_id}", true, s.getTemplate)
+	s.handle(mux, "PATCH /api/v2/templates/{template_id}", true, s.updateTemplate)
+	s.handle(mux, "DELETE /api/v2/templates/{template_id}", true, s.deleteTemplate)
+	return mux
+}
@@ -0,0 +1,240 @@
+// Package qiitatest provides a stateful in-memory fake of Qiita API v2 for integration tests.
+//
+// A Server keeps users, groups, items, comments, tags, stocks, follows, reactions, likes, projects and
+// templates in memory, so that an item created through a client can be listed back:
+//
+//	s := qiitatest.NewServer()
+//	defer s.Close()
+//	token := s.AddUser(qiita.User{Id: "qiita"})
+//	c := s.Client(token)
+//	item, _ := c.CreateItem(ctx, qiita.Item{Title: "Example", Body: "# Example", Tags: qiita.Taggings{{Name: "Go"}}})
+//	items, page, _ := c.ListUserItems(ctx, "qiita", 1, 20)
+//
+// Like Qiita API v2, it authenticates requests with bearer tokens, paginates lists with the Link and
+// Total-Count headers, reports the rate limit with the Rate-Limit, Rate-Remaining and Rate-Reset headers,
+// and responds to failed requests with a JSON body having a message and a type.
+//
+// The search query of GET /api/v2/items only supports the tag:, user:, title: and body: qualifiers
+// and plain words, all of which must match.
+package qiitatest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Rate limits of Qiita API v2 per hour.
+const (
+	AuthenticatedRateLimit = 1000
+	AnonymousRateLimit     = 60
+)
+
+// A fake Qiita API v2 server. Its state is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu  sync.Mutex
+	now func() time.Time
+
+	authenticatedLimit uint
+	anonymousLimit     uint
+	buckets            map[string]*bucket
+
+	tokens         map[string]qiita.UserId
+	users          []*qiita.User
+	groups         []*qiita.Group
+	items          []*qiita.Item
+	comments       []*comment
+	tags           []*qiita.Tag
+	stocks         []stock
+	likes          []like
+	follows        []follow
+	tagFollows     []tagFollow
+	reactions      []reaction
+	projects       []*qiita.Project
+	templates      []*qiita.Template
+	lastProjectId  qiita.ProjectId
+	lastTemplateId qiita.TemplateId
+}
+
+// Rate limit of a token, or of anonymous requests for the empty token.
+type bucket struct {
+	remaining uint
+	reset     time.Time
+}
+
+type comment struct {
+	qiita.Comment
+	itemId    qiita.ItemId
+	projectId qiita.ProjectId
+	userId    qiita.UserId
+}
+
+type stock struct {
+	itemId qiita.ItemId
+	userId qiita.UserId
+}
+
+type like struct {
+	itemId    qiita.ItemId
+	userId    qiita.UserId
+	createdAt qiita.Time
+}
+
+type follow struct {
+	follower qiita.UserId
+	followee qiita.UserId
+}
+
+type tagFollow struct {
+	userId qiita.UserId
+	tagId  qiita.TagId
+}
+
+// An emoji reaction on the item, comment or project identified by target, e.g. "items/c686397e4a0f4f11683d".
+type reaction struct {
+	target    string
+	userId    qiita.UserId
+	name      string
+	createdAt qiita.Time
+}
+
+// Starts a server, which should be closed with Close when it is no longer used.
+func NewServer() *Server {
+	s := &Server{
+		now:                time.Now,
+		authenticatedLimit: AuthenticatedRateLimit,
+		anonymousLimit:     AnonymousRateLimit,
+		buckets:            map[string]*bucket{},
+		tokens:             map[string]qiita.UserId{},
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Returns a client of the server authenticated with token, or an anonymous one for the empty token.
+func (s *Server) Client(token string) *qiita.Client {
+	c, err := qiita.NewClient(token, *qiita.NewConfig().WithEndpoint(s.URL))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Registers a user and returns a new access token of the user.
+func (s *Server) AddUser(user qiita.User) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user.Id == "" {
+		panic("qiitatest: user id is empty")
+	}
+	if s.user(user.Id) == nil {
+		user.PermanentId = uint(len(s.users) + 1)
+		s.users = append(s.users, &user)
+	}
+	token := randomHex(20)
+	s.tokens[token] = user.Id
+	return token
+}
+
+// Changes the number of requests per hour allowed for each token and for anonymous requests,
+// and restores the full limit of every token.
+func (s *Server) SetRateLimit(authenticated, anonymous uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authenticatedLimit = authenticated
+	s.anonymousLimit = anonymous
+	s.buckets = map[string]*bucket{}
+}
+
+// Replaces the clock of the server, which stamps items and comments and resets rate limits.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// A handler of an endpoint, given the id of the authenticated user or "" for an anonymous request.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, me qiita.UserId)
+
+// handle registers h for pattern. The server state is locked while h runs. An endpoint requiring
+// authentication rejects anonymous requests.
+func (s *Server) handle(mux *router, pattern string, auth bool, h handlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("X-Request-Id", randomHex(16))
+		me, token, ok := s.authenticate(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+			return
+		}
+		if !s.limit(w, token) {
+			writeError(w, http.StatusForbidden, "rate_limit_exceeded", "Rate limit exceeded")
+			return
+		}
+		if auth && me == "" {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+			return
+		}
+		h(w, r, me)
+	})
+}
+
+// authenticate returns the user of the bearer token of r. A request without a token is anonymous,
+// and one with an unknown token fails.
+func (s *Server) authenticate(r *http.Request) (qiita.UserId, string, bool) {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return "", "", true
+	}
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return "", "", false
+	}
+	userId, ok := s.tokens[token]
+	return userId, token, ok
+}
+
+// limit counts a request against the rate limit of token and sets the rate limit headers.
+// It reports false if the limit is exhausted.
+func (s *Server) limit(w http.ResponseWriter, token string) bool {
+	limit := s.authenticatedLimit
+	if token == "" {
+		limit = s.anonymousLimit
+	}
+	now := s.now()
+	b := s.buckets[token]
+	if b == nil || !now.Before(b.reset) {
+		b = &bucket{remaining: limit, reset: now.Add(time.Hour)}
+		s.buckets[token] = b
+	}
+	ok := b.remaining > 0
+	if ok {
+		b.remaining--
+	}
+	w.Header().Set("Rate-Limit", fmtUint(limit))
+	w.Header().Set("Rate-Remaining", fmtUint(b.remaining))
+	w.Header().Set("Rate-Reset", fmtUint(uint(b.reset.Unix())))
+	return ok
+}
+
+// timestamp returns the current time as Qiita API v2 reports it, in seconds and Japan time.
+func (s *Server) timestamp() qiita.Time {
+	return qiita.Time{Time: s.now().Truncate(time.Second).In(jst)}
+}
+
+var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+func randomHex(n int) string {
+	b := make([]byte, n/2)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
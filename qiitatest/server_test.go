@@ -0,0 +1,351 @@
+package qiitatest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+func newItem(title string, tags ...string) qiita.Item {
+	item := qiita.Item{Title: title, Body: "# " + title}
+	for _, tag := range tags {
+		item.Tags = append(item.Tags, qiita.Tagging{Name: tag})
+	}
+	return item
+}
+
+func TestItems(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+
+	created, err := c.CreateItem(ctx, newItem("Example", "Go", "C#"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Id == "" || created.User.Id != "qiita" || created.CreatedAt.IsZero() {
+		t.Errorf("created = %+v", created)
+	}
+
+	items, page, err := c.ListUserItems(ctx, "qiita", 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*items) != 1 || (*items)[0].Id != created.Id || page.TotalCount != 1 {
+		t.Errorf("items = %+v, page = %+v", items, page)
+	}
+	for _, tagId := range []qiita.TagId{"go", "c#"} {
+		items, _, err := c.ListTaggedItems(ctx, tagId, 1, 20)
+		if err != nil || len(*items) != 1 {
+			t.Errorf("ListTaggedItems(%q) = %v, %v", tagId, items, err)
+		}
+	}
+
+	created.Title = "Updated"
+	updated, err := c.UpdateItem(ctx, *created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.GetItem(ctx, created.Id); got.Title != "Updated" || updated.Title != "Updated" {
+		t.Errorf("title = %q", got.Title)
+	}
+
+	if err := c.DeleteItem(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetItem(ctx, created.Id); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestItemValidation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	_, err := c.CreateItem(context.TODO(), newItem("No tags"))
+	var apiErr *qiita.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Type != "bad_request" || apiErr.Message == "" {
+		t.Errorf("err = %v, want a bad_request error with a message", err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	owner := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	other := s.Client(s.AddUser(qiita.User{Id: "yaotti"}))
+	ctx := context.TODO()
+
+	item, err := owner.CreateItem(ctx, newItem("Example", "Go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client("").GetItem(ctx, item.Id); err != nil {
+		t.Errorf("anonymous GetItem: %v", err)
+	}
+	if _, err := s.Client("").CreateItem(ctx, newItem("Example", "Go")); !errors.Is(err, qiita.ErrUnauthorized) {
+		t.Errorf("anonymous CreateItem: err = %v, want ErrUnauthorized", err)
+	}
+	if _, err := s.Client("invalid").GetItem(ctx, item.Id); !errors.Is(err, qiita.ErrUnauthorized) {
+		t.Errorf("GetItem with an invalid token: err = %v, want ErrUnauthorized", err)
+	}
+	if err := other.DeleteItem(ctx, item.Id); !errors.Is(err, qiita.ErrForbidden) {
+		t.Errorf("DeleteItem of another user: err = %v, want ErrForbidden", err)
+	}
+	me, err := other.GetAuthenticatedUser(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.Id != "yaotti" {
+		t.Errorf("authenticated user = %q", me.Id)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+	for i := range 25 {
+		if _, err := c.CreateItem(ctx, newItem(fmt.Sprintf("Item %d", i), "Go")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, page, err := c.ListItems(ctx, 2, 10, "tag:go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (qiita.Page{TotalCount: 25, First: 1, Prev: 1, Next: 3, Last: 3}); *page != want {
+		t.Errorf("page = %+v, want %+v", *page, want)
+	}
+	if len(*items) != 10 || (*items)[0].Title != "Item 14" {
+		t.Errorf("items = %d starting with %q", len(*items), (*items)[0].Title)
+	}
+
+	n := 0
+	for _, err := range c.AllItems(ctx, "") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 25 {
+		t.Errorf("AllItems yielded %d items, want 25", n)
+	}
+
+	if _, _, err := c.ListItems(ctx, 1, 101, ""); !errors.Is(err, qiita.ErrBadRequest) {
+		t.Errorf("err = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.SetRateLimit(2, 1)
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+
+	if _, err := c.GetUser(ctx, "qiita"); err != nil {
+		t.Fatal(err)
+	}
+	if rl := c.RateLimit(); rl.Limit != 2 || rl.Remaining != 1 || rl.Reset.IsZero() {
+		t.Errorf("rate limit = %+v", rl)
+	}
+	c.GetUser(ctx, "qiita")
+	if _, err := c.GetUser(ctx, "qiita"); !errors.Is(err, qiita.ErrRateLimited) {
+		t.Errorf("err = %v, want ErrRateLimited", err)
+	}
+
+	anonymous := s.Client("")
+	anonymous.GetUser(ctx, "qiita")
+	if _, err := anonymous.GetUser(ctx, "qiita"); !errors.Is(err, qiita.ErrRateLimited) {
+		t.Errorf("anonymous: err = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestStocksAndLikes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	author := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	reader := s.Client(s.AddUser(qiita.User{Id: "yaotti"}))
+	ctx := context.TODO()
+	item, err := author.CreateItem(ctx, newItem("Example", "Go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reader.EnsureItemStock(ctx, item.Id); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v before stocking, want ErrNotFound", err)
+	}
+	if err := reader.StockItem(ctx, item.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.EnsureItemStock(ctx, item.Id); err != nil {
+		t.Error(err)
+	}
+	if stocks, _, _ := reader.ListUserStocks(ctx, "yaotti", 1, 20); len(*stocks) != 1 {
+		t.Errorf("stocks = %v", stocks)
+	}
+	if stockers, _, _ := reader.ListStockers(ctx, item.Id, 1, 20); len(*stockers) != 1 || (*stockers)[0].Id != "yaotti" {
+		t.Errorf("stockers = %v", stockers)
+	}
+
+	if err := reader.LikeItem(ctx, item.Id); err != nil {
+		t.Fatal(err)
+	}
+	if likes, _ := reader.ListItemLikes(ctx, item.Id); len(*likes) != 1 {
+		t.Errorf("likes = %v", likes)
+	}
+	got, _ := reader.GetItem(ctx, item.Id)
+	if got.StocksCount != 1 || got.LikesCount != 1 {
+		t.Errorf("stocks_count = %d, likes_count = %d", got.StocksCount, got.LikesCount)
+	}
+
+	if err := reader.UnstockItem(ctx, item.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.UnstockItem(ctx, item.Id); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v unstocking twice, want ErrNotFound", err)
+	}
+}
+
+func TestFollows(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "yaotti"}))
+	author := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+	author.CreateItem(ctx, newItem("Example", "Go"))
+
+	if err := c.FollowUser(ctx, "qiita"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.EnsureFollowingUser(ctx, "qiita"); err != nil {
+		t.Error(err)
+	}
+	if followers, _, _ := c.ListFollowers(ctx, "qiita", 1, 20); len(*followers) != 1 || (*followers)[0].Id != "yaotti" {
+		t.Errorf("followers = %v", followers)
+	}
+	if u, _ := c.GetUser(ctx, "qiita"); u.FollowersCount != 1 || u.ItemsCount != 1 {
+		t.Errorf("user = %+v", u)
+	}
+	if err := c.FollowUser(ctx, "yaotti"); !errors.Is(err, qiita.ErrForbidden) {
+		t.Errorf("err = %v following oneself, want ErrForbidden", err)
+	}
+
+	if err := c.FollowTag(ctx, "go"); err != nil {
+		t.Fatal(err)
+	}
+	if tags, _, _ := c.ListFollowingTags(ctx, "yaotti", 1, 20); len(*tags) != 1 || (*tags)[0].Id != "Go" {
+		t.Errorf("following tags = %v", tags)
+	}
+	if tag, _ := c.GetTag(ctx, "Go"); tag.FollowersCount != 1 || tag.ItemsCount != 1 {
+		t.Errorf("tag = %+v", tag)
+	}
+	if err := c.FollowTag(ctx, "rust"); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v following an unknown tag, want ErrNotFound", err)
+	}
+	if err := c.UnfollowTag(ctx, "go"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCommentsAndReactions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+	item, err := c.CreateItem(ctx, newItem("Example", "Go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	posted, err := c.PostComment(ctx, item.Id, qiita.Comment{Body: "Nice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comments, _ := c.ListComments(ctx, item.Id); len(*comments) != 1 || (*comments)[0].Id != posted.Id {
+		t.Errorf("comments = %v", comments)
+	}
+	posted.Body = "Very nice"
+	if updated, err := c.UpdateComment(ctx, *posted); err != nil || updated.Body != "Very nice" {
+		t.Errorf("UpdateComment = %v, %v", updated, err)
+	}
+
+	if _, err := c.AddItemReaction(ctx, item.Id, qiita.Reaction{Name: "+1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddCommentReaction(ctx, posted.Id, qiita.Reaction{Name: "tada"}); err != nil {
+		t.Fatal(err)
+	}
+	if reactions, _ := c.ListItemReactions(ctx, item.Id); len(*reactions) != 1 || (*reactions)[0].User.Id != "qiita" {
+		t.Errorf("reactions = %v", reactions)
+	}
+	if got, _ := c.GetItem(ctx, item.Id); got.CommentsCount != 1 || got.ReactionsCount != 1 {
+		t.Errorf("comments_count = %d, reactions_count = %d", got.CommentsCount, got.ReactionsCount)
+	}
+	if err := c.DeleteItemReaction(ctx, item.Id, "+1"); err != nil {
+		t.Error(err)
+	}
+
+	if err := c.DeleteComment(ctx, posted.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListCommentReactions(ctx, posted.Id); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestProjectsAndTemplates(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client(s.AddUser(qiita.User{Id: "qiita"}))
+	ctx := context.TODO()
+
+	project, err := c.CreateProject(ctx, qiita.Project{Name: "Kobiri", Body: "# Kobiri"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PostProjectComment(ctx, project.Id, qiita.Comment{Body: "Nice"}); err != nil {
+		t.Fatal(err)
+	}
+	if comments, page, _ := c.ListProjectComments(ctx, project.Id, 1, 20); len(*comments) != 1 || page.TotalCount != 1 {
+		t.Errorf("project comments = %v", comments)
+	}
+	if _, err := c.AddProjectReaction(ctx, project.Id, qiita.Reaction{Name: "+1"}); err != nil {
+		t.Fatal(err)
+	}
+	project.Archived = true
+	if _, err := c.UpdateProject(ctx, *project); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.GetProject(ctx, project.Id); !got.Archived {
+		t.Error("project is not archived")
+	}
+	if err := c.DeleteProject(ctx, project.Id); err != nil {
+		t.Fatal(err)
+	}
+	if projects, _, _ := c.ListProjects(ctx, 1, 20); len(*projects) != 0 {
+		t.Errorf("projects = %v", projects)
+	}
+
+	template, err := c.CreateTemplate(ctx, qiita.Template{Name: "Daily report", Title: "Daily report", Body: "# Done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.GetTemplate(ctx, template.Id); got.Name != "Daily report" {
+		t.Errorf("template = %+v", got)
+	}
+	if err := c.DeleteTemplate(ctx, template.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetTemplate(ctx, template.Id); !errors.Is(err, qiita.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,127 @@
+package qiitatest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// tag returns the tag of id, which is case-insensitive as on Qiita.
+func (s *Server) tag(id qiita.TagId) *qiita.Tag {
+	for _, t := range s.tags {
+		if strings.EqualFold(string(t.Id), string(id)) {
+			return t
+		}
+	}
+	return nil
+}
+
+// ensureTags creates the tags of taggings which do not exist yet.
+func (s *Server) ensureTags(taggings qiita.Taggings) {
+	for _, t := range taggings {
+		if s.tag(qiita.TagId(t.Name)) == nil {
+			s.tags = append(s.tags, &qiita.Tag{Id: qiita.TagId(t.Name)})
+		}
+	}
+}
+
+// renderTag returns t with its counters.
+func (s *Server) renderTag(t *qiita.Tag) qiita.Tag {
+	rendered := *t
+	rendered.ItemsCount, rendered.FollowersCount = 0, 0
+	for _, item := range s.items {
+		if !item.Private && hasTag(item, string(t.Id)) {
+			rendered.ItemsCount++
+		}
+	}
+	for _, f := range s.tagFollows {
+		if f.tagId == t.Id {
+			rendered.FollowersCount++
+		}
+	}
+	return rendered
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	tags := []qiita.Tag{}
+	for _, t := range slices.Backward(s.tags) {
+		tags = append(tags, s.renderTag(t))
+	}
+	switch r.URL.Query().Get("sort") {
+	case "", "count":
+		slices.SortStableFunc(tags, func(a, b qiita.Tag) int {
+			return cmp.Compare(b.ItemsCount, a.ItemsCount)
+		})
+	case "name":
+		slices.SortStableFunc(tags, func(a, b qiita.Tag) int {
+			return cmp.Compare(strings.ToLower(string(a.Id)), strings.ToLower(string(b.Id)))
+		})
+	default:
+		badRequest(w, "sort must be count or name")
+		return
+	}
+	writePage(w, r, tags)
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	t := s.tag(qiita.TagId(r.PathValue("tag_id")))
+	if t == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderTag(t))
+}
+
+func (s *Server) listFollowingTags(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	tags := []qiita.Tag{}
+	for _, f := range slices.Backward(s.tagFollows) {
+		if f.userId == u.Id {
+			tags = append(tags, s.renderTag(s.tag(f.tagId)))
+		}
+	}
+	writePage(w, r, tags)
+}
+
+func (s *Server) tagFollowIndex(userId qiita.UserId, tagId qiita.TagId) int {
+	return slices.IndexFunc(s.tagFollows, func(f tagFollow) bool {
+		return f.userId == userId && strings.EqualFold(string(f.tagId), string(tagId))
+	})
+}
+
+func (s *Server) getTagFollowing(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	if s.tagFollowIndex(me, qiita.TagId(r.PathValue("tag_id"))) < 0 {
+		notFound(w)
+		return
+	}
+	noContent(w)
+}
+
+func (s *Server) followTag(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	t := s.tag(qiita.TagId(r.PathValue("tag_id")))
+	if t == nil {
+		notFound(w)
+		return
+	}
+	if s.tagFollowIndex(me, t.Id) < 0 {
+		s.tagFollows = append(s.tagFollows, tagFollow{me, t.Id})
+	}
+	noContent(w)
+}
+
+func (s *Server) unfollowTag(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	i := s.tagFollowIndex(me, qiita.TagId(r.PathValue("tag_id")))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	s.tagFollows = slices.Delete(s.tagFollows, i, i+1)
+	noContent(w)
+}
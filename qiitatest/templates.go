@@ -0,0 +1,92 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// template returns the template of the id in a path, or nil if it is malformed or unknown.
+func (s *Server) template(id string) *qiita.Template {
+	n, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return nil
+	}
+	for _, t := range s.templates {
+		if t.Id == qiita.TemplateId(n) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	templates := []qiita.Template{}
+	for _, t := range s.templates {
+		templates = append(templates, *t)
+	}
+	writePage(w, r, templates)
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	var template qiita.Template
+	if !decode(w, r, &template) {
+		return
+	}
+	if template.Name == "" {
+		badRequest(w, "Name is empty")
+		return
+	}
+	s.lastTemplateId++
+	created := &qiita.Template{
+		Body:  template.Body,
+		Id:    s.lastTemplateId,
+		Name:  template.Name,
+		Tags:  template.Tags,
+		Title: template.Title,
+	}
+	s.templates = append(s.templates, created)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	template := s.template(r.PathValue("template_id"))
+	if template == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	template := s.template(r.PathValue("template_id"))
+	if template == nil {
+		notFound(w)
+		return
+	}
+	var update qiita.Template
+	if !decode(w, r, &update) {
+		return
+	}
+	if update.Name == "" {
+		badRequest(w, "Name is empty")
+		return
+	}
+	template.Body = update.Body
+	template.Name = update.Name
+	template.Tags = update.Tags
+	template.Title = update.Title
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) deleteTemplate(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	template := s.template(r.PathValue("template_id"))
+	if template == nil {
+		notFound(w)
+		return
+	}
+	s.templates = slices.DeleteFunc(s.templates, func(t *qiita.Template) bool { return t == template })
+	noContent(w)
+}
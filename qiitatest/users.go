@@ -0,0 +1,136 @@
+package qiitatest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ktsujichan/qiita-sdk-go/qiita"
+)
+
+// Quota of image uploads reported for every authenticated user.
+const imageMonthlyUploadLimit = 100 * 1024 * 1024
+
+func (s *Server) user(id qiita.UserId) *qiita.User {
+	for _, u := range s.users {
+		if u.Id == id {
+			return u
+		}
+	}
+	return nil
+}
+
+// renderUser returns u with its counters.
+func (s *Server) renderUser(u *qiita.User) qiita.User {
+	rendered := *u
+	rendered.ItemsCount, rendered.FolloweesCount, rendered.FollowersCount = 0, 0, 0
+	for _, item := range s.items {
+		if item.User.Id == u.Id && !item.Private {
+			rendered.ItemsCount++
+		}
+	}
+	for _, f := range s.follows {
+		if f.follower == u.Id {
+			rendered.FolloweesCount++
+		}
+		if f.followee == u.Id {
+			rendered.FollowersCount++
+		}
+	}
+	return rendered
+}
+
+func (s *Server) renderUserId(id qiita.UserId) qiita.User {
+	return s.renderUser(s.user(id))
+}
+
+func (s *Server) getAuthenticatedUser(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.renderUserId(me)
+	writeJSON(w, http.StatusOK, qiita.AuthenticatedUser{
+		User:                        &u,
+		ImageMonthlyUploadLimit:     imageMonthlyUploadLimit,
+		ImageMonthlyUploadRemaining: imageMonthlyUploadLimit,
+	})
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	var users []qiita.User
+	for _, u := range slices.Backward(s.users) {
+		users = append(users, s.renderUser(u))
+	}
+	writePage(w, r, users)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(u))
+}
+
+func (s *Server) listFollowees(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	s.listFollows(w, r, func(f follow, id qiita.UserId) (qiita.UserId, bool) {
+		return f.followee, f.follower == id
+	})
+}
+
+func (s *Server) listFollowers(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	s.listFollows(w, r, func(f follow, id qiita.UserId) (qiita.UserId, bool) {
+		return f.follower, f.followee == id
+	})
+}
+
+// listFollows lists the users related to the user of the request by match, in newest order.
+func (s *Server) listFollows(w http.ResponseWriter, r *http.Request, match func(f follow, id qiita.UserId) (qiita.UserId, bool)) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	var users []qiita.User
+	for _, f := range slices.Backward(s.follows) {
+		if id, ok := match(f, u.Id); ok {
+			users = append(users, s.renderUserId(id))
+		}
+	}
+	writePage(w, r, users)
+}
+
+func (s *Server) followIndex(follower, followee qiita.UserId) int {
+	return slices.Index(s.follows, follow{follower, followee})
+}
+
+func (s *Server) getFollowing(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	if s.followIndex(me, qiita.UserId(r.PathValue("user_id"))) < 0 {
+		notFound(w)
+		return
+	}
+	noContent(w)
+}
+
+func (s *Server) followUser(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	u := s.user(qiita.UserId(r.PathValue("user_id")))
+	if u == nil {
+		notFound(w)
+		return
+	}
+	if u.Id == me {
+		forbidden(w)
+		return
+	}
+	if s.followIndex(me, u.Id) < 0 {
+		s.follows = append(s.follows, follow{me, u.Id})
+	}
+	noContent(w)
+}
+
+func (s *Server) unfollowUser(w http.ResponseWriter, r *http.Request, me qiita.UserId) {
+	i := s.followIndex(me, qiita.UserId(r.PathValue("user_id")))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	s.follows = slices.Delete(s.follows, i, i+1)
+	noContent(w)
+}